@@ -0,0 +1,104 @@
+package collector
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+)
+
+// splitPointAddress splits the point address into the host and port, the port is empty if missing
+func splitPointAddress(addr string) (host, port string) {
+	if h, p, err := net.SplitHostPort(addr); err == nil {
+		return h, p
+	}
+	return strings.Trim(addr, "[]"), ""
+}
+
+// normalizeHost returns IPv4-mapped IPv6 addresses in the dotted form, other IPs in the canonical form and lowercase host names
+func normalizeHost(host string) string {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+	return strings.ToLower(host)
+}
+
+// normalizePointAddress normalizes the host part of the point address so the same point is always reported the same way
+func normalizePointAddress(addr string) string {
+	host, port := splitPointAddress(addr)
+	host = normalizeHost(host)
+	if port == "" {
+		return host
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// addressFamily returns "ipv4" or "ipv6" for IP addresses and "hostname" otherwise. IPv4-mapped IPv6 addresses are IPv4.
+func addressFamily(host string) string {
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
+		return "hostname"
+	case ip.To4() != nil:
+		return "ipv4"
+	default:
+		return "ipv6"
+	}
+}
+
+// resolveFamily returns the family of the host resolving host names. "hostname" is returned if the name can't be resolved.
+func resolveFamily(ctx context.Context, host string) string {
+	family := addressFamily(host)
+	if family != "hostname" {
+		return family
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return family
+	}
+	return addressFamily(addrs[0].IP.String())
+}
+
+// hostFamilies remembers the resolved families of host names so each name is looked up once
+type hostFamilies struct {
+	mtx      sync.Mutex
+	families map[string]string
+}
+
+func newHostFamilies() *hostFamilies {
+	return &hostFamilies{families: make(map[string]string)}
+}
+
+// family returns the family of the host resolving unknown host names
+func (h *hostFamilies) family(ctx context.Context, host string) string {
+	if family := addressFamily(host); family != "hostname" {
+		return family
+	}
+
+	h.mtx.Lock()
+	family, ok := h.families[host]
+	h.mtx.Unlock()
+	if ok {
+		return family
+	}
+
+	family = resolveFamily(ctx, host)
+	if ctx.Err() == nil {
+		h.mtx.Lock()
+		h.families[host] = family
+		h.mtx.Unlock()
+	}
+	return family
+}
+
+// retain forgets the host names missing from the given set
+func (h *hostFamilies) retain(hosts map[string]struct{}) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	for host := range h.families {
+		if _, ok := hosts[host]; !ok {
+			delete(h.families, host)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePointAddress(t *testing.T) {
+	tests := []struct {
+		addr, normalized, family string
+	}{
+		{"[::ffff:45.79.146.133]:9732", "45.79.146.133:9732", "ipv4"},
+		{"::ffff:45.79.146.133", "45.79.146.133", "ipv4"},
+		{"45.79.146.133:9732", "45.79.146.133:9732", "ipv4"},
+		{"[2001:0db8::0001]:9732", "[2001:db8::1]:9732", "ipv6"},
+		{"2001:db8::1", "2001:db8::1", "ipv6"},
+		{"Boot.Tzinit.org:9732", "boot.tzinit.org:9732", "hostname"},
+	}
+	for _, tc := range tests {
+		normalized := normalizePointAddress(tc.addr)
+		require.Equal(t, tc.normalized, normalized, tc.addr)
+		host, _ := splitPointAddress(normalized)
+		require.Equal(t, tc.family, addressFamily(host), tc.addr)
+	}
+}
+
+func TestHostFamilies(t *testing.T) {
+	h := newHostFamilies()
+	h.families["node.example.invalid"] = "ipv6"
+
+	// Remembered names aren't looked up again
+	require.Equal(t, "ipv6", h.family(context.Background(), "node.example.invalid"))
+	require.Equal(t, "ipv4", h.family(context.Background(), "45.79.146.133"))
+	require.NotContains(t, h.families, "45.79.146.133")
+
+	h.retain(map[string]struct{}{})
+	require.Empty(t, h.families)
+}
@@ -0,0 +1,40 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// AdminActionsCollector counts node state mutations (bans, trusts, connections and greylist clearing) made by tooling built
+// on the client, as the exporter itself never mutates the node state
+type AdminActionsCollector struct {
+	counter *prometheus.CounterVec
+}
+
+// NewAdminActionsCollector returns a new AdminActionsCollector. Use its HandleAdminAction method as tezos.RPCClient.AdminActionHandler.
+func NewAdminActionsCollector() *AdminActionsCollector {
+	return &AdminActionsCollector{
+		counter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_admin",
+				Name:      "actions_total",
+				Help:      "The total number of node admin actions requested including the failed ones.",
+			},
+			[]string{"action"},
+		),
+	}
+}
+
+// HandleAdminAction counts the action
+func (c *AdminActionsCollector) HandleAdminAction(action, target string, err error) {
+	c.counter.WithLabelValues(action).Inc()
+}
+
+// Describe implements prometheus.Collector
+func (c *AdminActionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.counter.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *AdminActionsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.counter.Collect(ch)
+}
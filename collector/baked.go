@@ -0,0 +1,83 @@
+package collector
+
+import (
+	"sync"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	lastBakedTimestampDesc = prometheus.NewDesc(
+		"tezos_baker_last_baked_block_timestamp_seconds",
+		"The timestamp of the last head block baked by the delegate.",
+		[]string{"delegate"},
+		nil)
+
+	lastBakedLevelDesc = prometheus.NewDesc(
+		"tezos_baker_last_baked_block_level",
+		"The level of the last head block baked by the delegate.",
+		[]string{"delegate"},
+		nil)
+)
+
+type bakedBlock struct {
+	level     int
+	timestamp float64
+}
+
+// LastBakedCollector tracks the last head block baked by each of the watched bakers. Bakers are reported once they bake a block.
+type LastBakedCollector struct {
+	mtx    sync.Mutex
+	blocks map[string]*bakedBlock
+}
+
+// NewLastBakedCollector returns a new LastBakedCollector fed by the block follower.
+func NewLastBakedCollector(follower *BlockFollower, bakers []string) *LastBakedCollector {
+	c := &LastBakedCollector{
+		blocks: make(map[string]*bakedBlock, len(bakers)),
+	}
+	for _, b := range bakers {
+		c.blocks[b] = nil
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+func (c *LastBakedCollector) handleBlock(block *tezos.Block) {
+	if !block.HasMetadata() {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	baker := block.Metadata.Baker
+	if _, ok := c.blocks[baker]; ok {
+		c.blocks[baker] = &bakedBlock{
+			level:     block.Header.Level,
+			timestamp: float64(block.Header.Timestamp.Unix()),
+		}
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *LastBakedCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- lastBakedTimestampDesc
+	ch <- lastBakedLevelDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *LastBakedCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for baker, b := range c.blocks {
+		if b == nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(lastBakedTimestampDesc, prometheus.GaugeValue, b.timestamp, baker)
+		ch <- prometheus.MustNewConstMetric(lastBakedLevelDesc, prometheus.GaugeValue, float64(b.level), baker)
+	}
+}
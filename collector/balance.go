@@ -0,0 +1,87 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// MaxBalanceAddresses is the maximum number of addresses watched by BalanceCollector
+const MaxBalanceAddresses = 100
+
+var (
+	accountBalanceDesc = prometheus.NewDesc(
+		"tezos_node_account_balance_mutez",
+		"The spendable balance of the watched implicit account.",
+		[]string{"address"},
+		nil)
+)
+
+// implicitPrefixes are the address prefixes of the implicit accounts
+var implicitPrefixes = []string{"tz1", "tz2", "tz3", "tz4"}
+
+func isImplicit(address string) bool {
+	for _, p := range implicitPrefixes {
+		if strings.HasPrefix(address, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// BalanceCollector reports the head block balances of the watched implicit accounts
+type BalanceCollector struct {
+	service   *tezos.Service
+	timeout   time.Duration
+	chainID   string
+	addresses []string
+}
+
+// NewBalanceCollector returns a new BalanceCollector. Only implicit account addresses are accepted.
+func NewBalanceCollector(service *tezos.Service, timeout time.Duration, chainID string, addresses []string) (*BalanceCollector, error) {
+	if len(addresses) > MaxBalanceAddresses {
+		return nil, fmt.Errorf("too many balance addresses: %d (max %d)", len(addresses), MaxBalanceAddresses)
+	}
+	for _, addr := range addresses {
+		if !isImplicit(addr) {
+			return nil, fmt.Errorf("not an implicit account address: %s", addr)
+		}
+	}
+
+	return &BalanceCollector{
+		service:   service,
+		timeout:   timeout,
+		chainID:   chainID,
+		addresses: addresses,
+	}, nil
+}
+
+// Describe implements prometheus.Collector
+func (c *BalanceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- accountBalanceDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *BalanceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, addr := range c.addresses {
+		balance, err := c.service.GetContractBalance(ctx, c.chainID, "head", addr)
+		var val float64
+		if err != nil {
+			log.WithError(err).WithField("address", addr).Error("error getting balance")
+			val = 1
+		} else {
+			v, _ := new(big.Float).SetInt(balance).Float64()
+			ch <- prometheus.MustNewConstMetric(accountBalanceDesc, prometheus.GaugeValue, v, addr)
+		}
+		ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/chains/"+c.chainID+"/blocks/head/context/contracts/"+addr+"/balance")
+	}
+}
@@ -0,0 +1,70 @@
+package collector
+
+import (
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// BlockOperationsCollector counts operations included into head blocks
+type BlockOperationsCollector struct {
+	counter *prometheus.CounterVec
+	rollups *prometheus.CounterVec
+	kinds   *KindLimiter
+	unknown *UnknownOperationKindsCollector
+}
+
+// NewBlockOperationsCollector returns a new BlockOperationsCollector fed by the block follower.
+// Operation kind label values are passed through kinds. Operations of unknown kinds are reported to unknown if not nil.
+func NewBlockOperationsCollector(follower *BlockFollower, kinds *KindLimiter, unknown *UnknownOperationKindsCollector) *BlockOperationsCollector {
+	c := &BlockOperationsCollector{
+		counter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "block",
+				Name:      "operations_total",
+				Help:      "The total number of operations included into head blocks. Blocks replaced by reorganisations are counted too.",
+			},
+			[]string{"kind"},
+		),
+		rollups: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "block",
+				Name:      "smart_rollup_operations_total",
+				Help:      "The total number of smart rollup operations included into head blocks by the operation result status.",
+			},
+			[]string{"kind", "status"},
+		),
+		kinds:   kinds,
+		unknown: unknown,
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+func (c *BlockOperationsCollector) handleBlock(block *tezos.Block) {
+	for _, pass := range block.Operations {
+		for _, op := range pass {
+			for _, elem := range op.Contents {
+				c.unknown.Observe(elem)
+				c.counter.WithLabelValues(c.kinds.Kind(elem.OperationElemKind())).Inc()
+				if r, ok := elem.(*tezos.SmartRollupOperationElem); ok && block.HasMetadata() {
+					c.rollups.WithLabelValues(r.Kind, r.Metadata.OperationResult.Status).Inc()
+				}
+			}
+		}
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *BlockOperationsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.counter.Describe(ch)
+	c.rollups.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *BlockOperationsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.counter.Collect(ch)
+	c.rollups.Collect(ch)
+}
@@ -0,0 +1,95 @@
+package collector
+
+import (
+	"strconv"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// BlockSizeCollector collects sizes of head blocks measured from the block RPC responses
+type BlockSizeCollector struct {
+	size    prometheus.Gauge
+	hist    prometheus.Histogram
+	opsSize prometheus.Gauge
+	opHist  *prometheus.HistogramVec
+}
+
+// validationPasses holds names of the operation list indices
+var validationPasses = []string{"consensus", "voting", "anonymous", "manager"}
+
+func validationPass(i int) string {
+	if i < len(validationPasses) {
+		return validationPasses[i]
+	}
+	return strconv.Itoa(i)
+}
+
+// NewBlockSizeCollector returns a new BlockSizeCollector fed by the block follower.
+func NewBlockSizeCollector(follower *BlockFollower) *BlockSizeCollector {
+	c := &BlockSizeCollector{
+		size: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tezos_node",
+			Subsystem: "head",
+			Name:      "block_size_bytes",
+			Help:      "Size of the head block RPC response including the header and operations.",
+		}),
+		hist: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "tezos_node",
+			Subsystem: "block",
+			Name:      "size_bytes",
+			Help:      "Sizes of the head blocks RPC responses including the header and operations.",
+			Buckets:   prometheus.ExponentialBuckets(1024, 2, 14),
+		}),
+		opsSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tezos_node",
+			Subsystem: "head",
+			Name:      "operations_json_bytes",
+			Help:      "Total length of the head block operations JSON data as received from the node, metadata included. It isn't the size of the operations binary encoding.",
+		}),
+		opHist: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "tezos_node",
+			Subsystem: "block",
+			Name:      "operation_json_bytes",
+			Help:      "Lengths of the head blocks operations JSON data as received from the node by the validation pass, metadata included. It isn't the size of the operations binary encoding.",
+			Buckets:   prometheus.ExponentialBuckets(128, 2, 12),
+		}, []string{"pass"}),
+	}
+
+	follower.SubscribeBlockSize(c.handleBlock)
+	return c
+}
+
+func (c *BlockSizeCollector) handleBlock(block *tezos.Block, size int64, opSizes [][]int) {
+	c.size.Set(float64(size))
+	c.hist.Observe(float64(size))
+
+	if opSizes == nil {
+		return
+	}
+	var total int
+	for i, pass := range opSizes {
+		hist := c.opHist.WithLabelValues(validationPass(i))
+		for _, n := range pass {
+			hist.Observe(float64(n))
+			total += n
+		}
+	}
+	c.opsSize.Set(float64(total))
+}
+
+// Describe implements prometheus.Collector
+func (c *BlockSizeCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.size.Describe(ch)
+	c.hist.Describe(ch)
+	c.opsSize.Describe(ch)
+	c.opHist.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *BlockSizeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.size.Collect(ch)
+	c.hist.Collect(ch)
+	c.opsSize.Collect(ch)
+	c.opHist.Collect(ch)
+}
@@ -0,0 +1,35 @@
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+)
+
+func TestBlockSizeCollector(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	// The operations are 14 and 30 bytes long as formatted
+	block := `{"protocol": "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt", "operations": [[{"hash": "o1"}], [], [], [{"hash": "o2", "contents": []}]]}`
+	srv.Handle("/monitor/heads/main", &tezostest.Response{Chunks: [][]byte{[]byte(`{"hash":"BKq199p1Hm1phfJ4DhuRjB6yBSJnDNG8sgMSnja9pXR96T2Hyy1","level":390397,"proto":3}` + "\n")}, Hold: true})
+	srv.Handle("/chains/main/blocks/*", &tezostest.Response{Body: []byte(block)})
+	srv.Handle("/chains/main/blocks/*/context/constants", tezostest.MustFixture(fixtures+"chains/constants.json"))
+
+	follower := NewBlockFollower(newTestService(t, srv), "main", time.Second, time.Hour, 0, nil, nil)
+	c := NewBlockSizeCollector(follower)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	follower.Start(ctx)
+
+	requireMetrics(t, c, `
+# HELP tezos_node_head_block_size_bytes Size of the head block RPC response including the header and operations.
+# TYPE tezos_node_head_block_size_bytes gauge
+tezos_node_head_block_size_bytes 143
+# HELP tezos_node_head_operations_json_bytes Total length of the head block operations JSON data as received from the node, metadata included. It isn't the size of the operations binary encoding.
+# TYPE tezos_node_head_operations_json_bytes gauge
+tezos_node_head_operations_json_bytes 44
+`, "tezos_node_head_block_size_bytes", "tezos_node_head_operations_json_bytes")
+}
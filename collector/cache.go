@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// TTLCache keeps results of slow RPCs for a limited time to share them between scrapes
+type TTLCache struct {
+	ttl     time.Duration
+	mtx     sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+// NewTTLCache returns a new TTLCache. Zero TTL disables caching.
+func NewTTLCache(ttl time.Duration) *TTLCache {
+	return &TTLCache{
+		ttl:     ttl,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// Get returns the cached value for the key or calls fetch and caches its result
+func (c *TTLCache) Get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	if c == nil || c.ttl == 0 {
+		return fetch()
+	}
+
+	c.mtx.Lock()
+	e, ok := c.entries[key]
+	c.mtx.Unlock()
+
+	if ok && time.Now().Before(e.expires) {
+		return e.value, nil
+	}
+
+	v, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	c.entries[key] = &cacheEntry{
+		value:   v,
+		expires: time.Now().Add(c.ttl),
+	}
+	c.mtx.Unlock()
+
+	return v, nil
+}
+
+// Invalidate drops cached values for the given keys
+func (c *TTLCache) Invalidate(keys ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for _, k := range keys {
+		delete(c.entries, k)
+	}
+}
+
+// InvalidateAll drops all cached values
+func (c *TTLCache) InvalidateAll() {
+	if c == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.entries = make(map[string]*cacheEntry)
+}
@@ -0,0 +1,67 @@
+package collector
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	chainInfoDesc = prometheus.NewDesc(
+		"tezos_node_chain_info",
+		"Hash of the monitored chain given by an alias like \"main\", e.g. NetXdQprcVkpaWU on mainnet.",
+		[]string{"chain", "chain_id"},
+		nil)
+)
+
+// ChainCollector resolves the monitored chain alias to the chain hash
+type ChainCollector struct {
+	service *tezos.Service
+	timeout time.Duration
+	chainID string
+
+	mtx  sync.Mutex
+	hash string
+}
+
+// NewChainCollector returns a new ChainCollector. The hash is fetched until it succeeds once as it doesn't change while the node runs.
+func NewChainCollector(service *tezos.Service, timeout time.Duration, chainID string) *ChainCollector {
+	return &ChainCollector{
+		service: service,
+		timeout: timeout,
+		chainID: chainID,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *ChainCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- chainInfoDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *ChainCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var val float64
+	if c.hash == "" {
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		defer cancel()
+
+		hash, err := c.service.GetChainID(ctx, c.chainID)
+		if err != nil {
+			log.WithError(err).Error("error getting chain ID")
+			val = 1
+		} else {
+			c.hash = hash
+		}
+	}
+	if c.hash != "" {
+		ch <- prometheus.MustNewConstMetric(chainInfoDesc, prometheus.GaugeValue, 1, c.chainID, c.hash)
+	}
+	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/chains/"+c.chainID+"/chain_id")
+}
@@ -0,0 +1,40 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainCollector(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/chains/main/chain_id",
+		&tezostest.Response{Status: 500, ContentType: "text/plain", Body: []byte("Internal Server Error")},
+		&tezostest.Response{Body: []byte(`"NetXdQprcVkpaWU"`)},
+	)
+
+	c := withRPCFailed{NewChainCollector(newTestService(t, srv), time.Second, "main")}
+	requireMetrics(t, c, `
+# HELP tezos_rpc_failed A gauge that is set to 1 when a metrics collection RPC failed during the current scrape, 0 otherwise.
+# TYPE tezos_rpc_failed gauge
+tezos_rpc_failed{rpc="/chains/main/chain_id"} 1
+`)
+
+	expected := `
+# HELP tezos_node_chain_info Hash of the monitored chain given by an alias like "main", e.g. NetXdQprcVkpaWU on mainnet.
+# TYPE tezos_node_chain_info gauge
+tezos_node_chain_info{chain="main",chain_id="NetXdQprcVkpaWU"} 1
+# HELP tezos_rpc_failed A gauge that is set to 1 when a metrics collection RPC failed during the current scrape, 0 otherwise.
+# TYPE tezos_rpc_failed gauge
+tezos_rpc_failed{rpc="/chains/main/chain_id"} 0
+`
+	requireMetrics(t, c, expected)
+	requireMetrics(t, c, expected)
+
+	// The hash is requested until it succeeds once
+	require.Equal(t, 2, srv.Requests("/chains/main/chain_id"))
+}
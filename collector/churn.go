@@ -0,0 +1,206 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// churnEvents is a set of peer and point log event kinds to be counted
+var churnEvents = map[string]struct{}{
+	"connection_established": {},
+	"external_disconnection": {},
+	"request_rejected":       {},
+}
+
+// ChurnCollector counts connection events reported by the peers and points log monitors.
+// Only running peers and points are monitored, the list is refreshed periodically.
+type ChurnCollector struct {
+	service     *tezos.Service
+	timeout     time.Duration
+	interval    time.Duration
+	maxStreams  int
+	gate        *StartupGate
+	peerEvents  *prometheus.CounterVec
+	pointEvents *prometheus.CounterVec
+	peers       map[string]context.CancelFunc
+	points      map[string]context.CancelFunc
+}
+
+// NewChurnCollector returns a new ChurnCollector. At most maxStreams peers and maxStreams points are monitored at once.
+// Monitoring starts once the gate opens.
+func NewChurnCollector(service *tezos.Service, timeout, interval time.Duration, maxStreams int, gate *StartupGate) *ChurnCollector {
+	c := &ChurnCollector{
+		service:    service,
+		gate:       gate,
+		timeout:    timeout,
+		interval:   interval,
+		maxStreams: maxStreams,
+		peerEvents: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "peer",
+				Name:      "events_total",
+				Help:      "The total number of connection events reported by the running peers logs.",
+			},
+			[]string{"event"},
+		),
+		pointEvents: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "point",
+				Name:      "events_total",
+				Help:      "The total number of connection events reported by the running points logs.",
+			},
+			[]string{"event"},
+		),
+		peers:  make(map[string]context.CancelFunc),
+		points: make(map[string]context.CancelFunc),
+	}
+
+	go c.refreshLoop()
+	return c
+}
+
+func (c *ChurnCollector) countEvent(vec *prometheus.CounterVec, kind string) {
+	if _, ok := churnEvents[kind]; ok {
+		vec.WithLabelValues(kind).Inc()
+	}
+}
+
+// runMonitor calls run until the context is cancelled
+func (c *ChurnCollector) runMonitor(ctx context.Context, run func() error) {
+	for {
+		err := run()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.WithError(err).Debug("error monitoring network log")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.interval):
+		}
+	}
+}
+
+// watchPeer counts peer log events until the context is cancelled.
+// Events older than since are ignored as the node replays the whole log on each (re)connection.
+func (c *ChurnCollector) watchPeer(ctx context.Context, id string, since time.Time) {
+	ch := make(chan []*tezos.NetworkPeerLogEntry, 10)
+	go func() {
+		for entries := range ch {
+			for _, e := range entries {
+				if e.Timestamp.After(since) {
+					since = e.Timestamp
+					c.countEvent(c.peerEvents, e.Kind)
+				}
+			}
+		}
+	}()
+
+	c.runMonitor(ctx, func() error { return c.service.MonitorNetworkPeerLog(ctx, id, ch) })
+	close(ch)
+}
+
+// watchPoint counts point log events until the context is cancelled. See watchPeer.
+func (c *ChurnCollector) watchPoint(ctx context.Context, address string, since time.Time) {
+	ch := make(chan []*tezos.NetworkPointLogEntry, 10)
+	go func() {
+		for entries := range ch {
+			for _, e := range entries {
+				if e.Timestamp.After(since) {
+					since = e.Timestamp
+					c.countEvent(c.pointEvents, e.Kind.EventKind)
+				}
+			}
+		}
+	}()
+
+	c.runMonitor(ctx, func() error { return c.service.MonitorNetworkPointLog(ctx, address, ch) })
+	close(ch)
+}
+
+// update starts watchers for new items and stops watchers for the ones which are gone
+func (c *ChurnCollector) update(watchers map[string]context.CancelFunc, ids []string, since time.Time, watch func(context.Context, string, time.Time)) {
+	current := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		current[id] = struct{}{}
+	}
+
+	for id, cancel := range watchers {
+		if _, ok := current[id]; !ok {
+			cancel()
+			delete(watchers, id)
+		}
+	}
+
+	for _, id := range ids {
+		if _, ok := watchers[id]; ok {
+			continue
+		}
+		if len(watchers) >= c.maxStreams {
+			break
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		watchers[id] = cancel
+		go watch(ctx, id, since)
+	}
+}
+
+func (c *ChurnCollector) refresh(since time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	peers, err := c.service.GetNetworkPeers(ctx, "running")
+	if err != nil {
+		log.WithError(err).Error("error getting running peers")
+	} else {
+		ids := make([]string, len(peers))
+		for i, p := range peers {
+			ids[i] = p.PeerID
+		}
+		c.update(c.peers, ids, since, c.watchPeer)
+	}
+
+	points, err := c.service.GetNetworkPoints(ctx, "running")
+	if err != nil {
+		log.WithError(err).Error("error getting running points")
+	} else {
+		ids := make([]string, len(points))
+		for i, p := range points {
+			ids[i] = p.Address
+		}
+		c.update(c.points, ids, since, c.watchPoint)
+	}
+}
+
+func (c *ChurnCollector) refreshLoop() {
+	c.gate.Wait()
+
+	// Items discovered during the refresh may have been connected since the previous one
+	since := time.Now()
+	for {
+		t := time.Now()
+		c.refresh(since)
+		since = t
+		<-time.After(c.interval)
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *ChurnCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.peerEvents.Describe(ch)
+	c.pointEvents.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *ChurnCollector) Collect(ch chan<- prometheus.Metric) {
+	c.peerEvents.Collect(ch)
+	c.pointEvents.Collect(ch)
+}
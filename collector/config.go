@@ -0,0 +1,33 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var configInfoDesc = prometheus.NewDesc(
+	"tezos_exporter_config_info",
+	"Effective exporter settings, a series per setting with its value. Always 1.",
+	[]string{"setting", "value"},
+	nil)
+
+// ConfigInfoCollector exports the effective exporter settings so configuration drift can be found with queries
+type ConfigInfoCollector struct {
+	settings map[string]string
+}
+
+// NewConfigInfoCollector returns a new ConfigInfoCollector exporting the settings given by name
+func NewConfigInfoCollector(settings map[string]string) *ConfigInfoCollector {
+	return &ConfigInfoCollector{settings: settings}
+}
+
+// Describe implements prometheus.Collector
+func (c *ConfigInfoCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- configInfoDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *ConfigInfoCollector) Collect(ch chan<- prometheus.Metric) {
+	for setting, value := range c.settings {
+		ch <- prometheus.MustNewConstMetric(configInfoDesc, prometheus.GaugeValue, 1, setting, value)
+	}
+}
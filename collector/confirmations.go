@@ -0,0 +1,144 @@
+package collector
+
+import (
+	"errors"
+	"sync"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MaxWatchedOperations is the maximum number of operations tracked by ConfirmationCollector at once
+const MaxWatchedOperations = 1000
+
+// ConfirmedRetention is the number of blocks an operation keeps being reported at the target depth before it's forgotten
+const ConfirmedRetention = 10
+
+// ErrWatchListFull is returned by ConfirmationCollector.Watch if no more operations can be tracked
+var ErrWatchListFull = errors.New("watch list is full")
+
+var (
+	confirmationsDesc = prometheus.NewDesc(
+		"tezos_node_watched_operation_confirmations",
+		"The number of blocks on top of the block including the watched operation, -1 if not included yet. Reported for a few blocks after the target depth is reached.",
+		[]string{"operation"},
+		nil)
+)
+
+type watchedOp struct {
+	level int
+}
+
+// ConfirmationCollector tracks the confirmation depth of the registered operations
+type ConfirmationCollector struct {
+	target int
+
+	mtx  sync.Mutex
+	head int
+	ops  map[string]*watchedOp
+}
+
+// NewConfirmationCollector returns a new ConfirmationCollector fed by the block follower.
+func NewConfirmationCollector(follower *BlockFollower, target int) *ConfirmationCollector {
+	c := &ConfirmationCollector{
+		target: target,
+		ops:    make(map[string]*watchedOp),
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+// Watch registers the operation hash. Registering an already watched operation has no effect.
+func (c *ConfirmationCollector) Watch(hash string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.ops[hash]; ok {
+		return nil
+	}
+	if len(c.ops) >= MaxWatchedOperations {
+		return ErrWatchListFull
+	}
+	c.ops[hash] = &watchedOp{}
+	return nil
+}
+
+// Unwatch removes the operation hash. Returns false if it wasn't watched.
+func (c *ConfirmationCollector) Unwatch(hash string) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.ops[hash]; !ok {
+		return false
+	}
+	delete(c.ops, hash)
+	return true
+}
+
+// depth must be called with the lock held
+func (c *ConfirmationCollector) depth(op *watchedOp) int {
+	if op.level == 0 {
+		return -1
+	}
+	if d := c.head - op.level; d < c.target {
+		return d
+	}
+	return c.target
+}
+
+// Watched returns confirmation depths of the watched operations
+func (c *ConfirmationCollector) Watched() map[string]int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	res := make(map[string]int, len(c.ops))
+	for hash, op := range c.ops {
+		res[hash] = c.depth(op)
+	}
+	return res
+}
+
+func (c *ConfirmationCollector) handleBlock(block *tezos.Block) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	level := block.Header.Level
+	c.head = level
+	if len(c.ops) == 0 {
+		return
+	}
+
+	for hash, op := range c.ops {
+		switch {
+		case op.level >= level:
+			// The including block was replaced by a reorganisation
+			op.level = 0
+		case op.level != 0 && level-op.level >= c.target+ConfirmedRetention:
+			delete(c.ops, hash)
+		}
+	}
+
+	for _, pass := range block.Operations {
+		for _, op := range pass {
+			if w, ok := c.ops[op.Hash]; ok && w.level == 0 {
+				w.level = level
+			}
+		}
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *ConfirmationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- confirmationsDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *ConfirmationCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for hash, op := range c.ops {
+		ch <- prometheus.MustNewConstMetric(confirmationsDesc, prometheus.GaugeValue, float64(c.depth(op)), hash)
+	}
+}
@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"testing"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/stretchr/testify/require"
+)
+
+func confirmationsBlock(level int, ops ...string) *tezos.Block {
+	pass := make([]*tezos.Operation, len(ops))
+	for i, hash := range ops {
+		pass[i] = &tezos.Operation{Hash: hash}
+	}
+	return &tezos.Block{Header: tezos.RawBlockHeader{Level: level}, Operations: [][]*tezos.Operation{pass}}
+}
+
+func TestConfirmationCollector(t *testing.T) {
+	c := &ConfirmationCollector{target: 2, ops: make(map[string]*watchedOp)}
+	require.NoError(t, c.Watch("oo1"))
+	require.NoError(t, c.Watch("oo2"))
+
+	c.handleBlock(confirmationsBlock(100))
+	require.Equal(t, map[string]int{"oo1": -1, "oo2": -1}, c.Watched())
+
+	c.handleBlock(confirmationsBlock(101, "oo1"))
+	c.handleBlock(confirmationsBlock(102, "oo2"))
+	require.Equal(t, map[string]int{"oo1": 1, "oo2": 0}, c.Watched())
+
+	// The block including oo2 is replaced
+	c.handleBlock(confirmationsBlock(102))
+	require.Equal(t, map[string]int{"oo1": 1, "oo2": -1}, c.Watched())
+
+	c.handleBlock(confirmationsBlock(103, "oo2"))
+	require.Equal(t, map[string]int{"oo1": 2, "oo2": 0}, c.Watched())
+
+	// Scrapes don't forget operations which reached the target depth
+	expected := `
+# HELP tezos_node_watched_operation_confirmations The number of blocks on top of the block including the watched operation, -1 if not included yet. Reported for a few blocks after the target depth is reached.
+# TYPE tezos_node_watched_operation_confirmations gauge
+tezos_node_watched_operation_confirmations{operation="oo1"} 2
+tezos_node_watched_operation_confirmations{operation="oo2"} 0
+`
+	requireMetrics(t, c, expected)
+	requireMetrics(t, c, expected)
+
+	// oo1 is forgotten after being reported at the target depth for ConfirmedRetention blocks
+	for level := 104; level < 101+2+ConfirmedRetention; level++ {
+		c.handleBlock(confirmationsBlock(level))
+	}
+	require.Equal(t, map[string]int{"oo1": 2, "oo2": 2}, c.Watched())
+	c.handleBlock(confirmationsBlock(101 + 2 + ConfirmedRetention))
+	require.Equal(t, map[string]int{"oo2": 2}, c.Watched())
+}
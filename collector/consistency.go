@@ -0,0 +1,94 @@
+package collector
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	headBackwardsDesc = prometheus.NewDesc(
+		"tezos_node_rpc_head_level_backwards",
+		"A gauge that is set to 1 when the head level reported by the RPC went backwards since the previous scrape, which indicates an inconsistent pool of nodes behind a load balancer.",
+		nil,
+		nil)
+)
+
+// ConsistencyCollector cross-checks the head level reported by the heads stream against the head block RPC
+// and detects the head level going backwards between scrapes
+type ConsistencyCollector struct {
+	service      *tezos.Service
+	timeout      time.Duration
+	chainID      string
+	inconsistent prometheus.Counter
+
+	mtx      sync.Mutex
+	level    int
+	rpcLevel int
+}
+
+// NewConsistencyCollector returns a new ConsistencyCollector fed by the block follower.
+func NewConsistencyCollector(service *tezos.Service, timeout time.Duration, chainID string, follower *BlockFollower) *ConsistencyCollector {
+	c := &ConsistencyCollector{
+		service: service,
+		timeout: timeout,
+		chainID: chainID,
+		inconsistent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "tezos_node",
+			Name:      "rpc_inconsistency_total",
+			Help:      "The total number of times the head level reported by the heads stream diverged from the head block RPC by more than one block.",
+		}),
+	}
+
+	follower.SubscribeHeads(c.handleHead)
+	return c
+}
+
+func (c *ConsistencyCollector) handleHead(head *tezos.BlockInfo) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.level = head.Level
+}
+
+// Describe implements prometheus.Collector.
+func (c *ConsistencyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- headBackwardsDesc
+	c.inconsistent.Describe(ch)
+}
+
+// Collect implements prometheus.Collector and is called by the Prometheus registry when collecting metrics.
+func (c *ConsistencyCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	header, err := c.service.GetBlockHeader(ctx, c.chainID, "head")
+	var val float64
+	if err != nil {
+		log.WithError(err).Error("error getting head block header")
+		val = 1
+	} else {
+		c.mtx.Lock()
+		level, prevLevel := c.level, c.rpcLevel
+		c.rpcLevel = header.Level
+		c.mtx.Unlock()
+
+		if diff := header.Level - level; level != 0 && (diff > 1 || diff < -1) {
+			log.WithFields(log.Fields{"stream_level": level, "rpc_level": header.Level}).Warn("head level inconsistency detected")
+			c.inconsistent.Inc()
+		}
+
+		var backwards float64
+		if header.Level < prevLevel {
+			log.WithFields(log.Fields{"previous_level": prevLevel, "rpc_level": header.Level}).Warn("head level went backwards")
+			backwards = 1
+		}
+		ch <- prometheus.MustNewConstMetric(headBackwardsDesc, prometheus.GaugeValue, backwards)
+	}
+	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/chains/"+c.chainID+"/blocks/head/header")
+
+	c.inconsistent.Collect(ch)
+}
@@ -0,0 +1,75 @@
+package collector
+
+import (
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func newConstantDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc("tezos_node_constants_"+name, help, nil, nil)
+}
+
+type constantDesc struct {
+	desc  *prometheus.Desc
+	value func(c *tezos.Constants) int64
+}
+
+// constantDescs lists the exported constants. Constants missing in the current protocol are skipped.
+var constantDescs = []constantDesc{
+	{
+		desc:  newConstantDesc("minimal_block_delay_seconds", "Minimal delay between two consecutive blocks."),
+		value: func(c *tezos.Constants) int64 { return c.MinimalBlockDelay },
+	},
+	{
+		desc:  newConstantDesc("blocks_per_cycle", "Number of blocks in a cycle."),
+		value: func(c *tezos.Constants) int64 { return int64(c.BlocksPerCycle) },
+	},
+	{
+		desc:  newConstantDesc("consensus_committee_size", "Number of endorsement slots per block."),
+		value: func(c *tezos.Constants) int64 { return int64(c.MaxEndorsementPower()) },
+	},
+	{
+		desc:  newConstantDesc("hard_gas_limit_per_operation", "Maximum gas an operation may consume."),
+		value: func(c *tezos.Constants) int64 { return c.HardGasLimitPerOperation },
+	},
+	{
+		desc:  newConstantDesc("hard_gas_limit_per_block", "Maximum gas the operations of a block may consume."),
+		value: func(c *tezos.Constants) int64 { return c.HardGasLimitPerBlock },
+	},
+	{
+		desc:  newConstantDesc("hard_storage_limit_per_operation", "Maximum storage in bytes an operation may allocate."),
+		value: func(c *tezos.Constants) int64 { return c.HardStorageLimitPerOperation },
+	},
+}
+
+// ConstantsCollector exposes the key constants of the head protocol. The block follower refreshes them on protocol change.
+type ConstantsCollector struct {
+	follower *BlockFollower
+}
+
+// NewConstantsCollector returns a new ConstantsCollector.
+func NewConstantsCollector(follower *BlockFollower) *ConstantsCollector {
+	return &ConstantsCollector{
+		follower: follower,
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *ConstantsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range constantDescs {
+		ch <- d.desc
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *ConstantsCollector) Collect(ch chan<- prometheus.Metric) {
+	constants := c.follower.Constants()
+	if constants == nil {
+		return
+	}
+	for _, d := range constantDescs {
+		if v := d.value(constants); v != 0 {
+			ch <- prometheus.MustNewConstMetric(d.desc, prometheus.GaugeValue, float64(v))
+		}
+	}
+}
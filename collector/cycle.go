@@ -0,0 +1,145 @@
+package collector
+
+import (
+	"math/big"
+	"strconv"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// denunciationKinds is a set of double signing evidence operation kinds
+var denunciationKinds = map[string]struct{}{
+	"double_baking_evidence":         {},
+	"double_endorsement_evidence":    {},
+	"double_preendorsement_evidence": {},
+	"double_attestation_evidence":    {},
+	"double_preattestation_evidence": {},
+}
+
+// CycleCollector collects selected head block counters keyed by cycle. Every level is counted once and only the last retention cycles are kept.
+type CycleCollector struct {
+	follower      *BlockFollower
+	window        cycleWindow
+	counted       levelGuard
+	position      prometheus.Gauge
+	progress      prometheus.Gauge
+	blocks        *prometheus.CounterVec
+	fees          *prometheus.CounterVec
+	denunciations *prometheus.CounterVec
+}
+
+// NewCycleCollector returns a new CycleCollector fed by the block follower.
+func NewCycleCollector(follower *BlockFollower, retention int) *CycleCollector {
+	c := &CycleCollector{
+		follower: follower,
+		window:   cycleWindow{size: retention},
+		position: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tezos_node",
+			Subsystem: "cycle",
+			Name:      "position",
+			Help:      "The position of the head block within its cycle, starting from zero.",
+		}),
+		progress: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tezos_node",
+			Subsystem: "cycle",
+			Name:      "progress_ratio",
+			Help:      "The part of the cycle completed by the head block, see also tezos_node_constants_blocks_per_cycle.",
+		}),
+		blocks: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "cycle",
+				Name:      "blocks_total",
+				Help:      "The total number of head blocks observed during the cycle.",
+			},
+			[]string{"cycle"},
+		),
+		fees: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "cycle",
+				Name:      "fees_mutez_total",
+				Help:      "The total amount of operation fees included into head blocks during the cycle.",
+			},
+			[]string{"cycle"},
+		),
+		denunciations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "cycle",
+				Name:      "denunciations_total",
+				Help:      "The total number of double signing evidence operations included into head blocks during the cycle.",
+			},
+			[]string{"cycle"},
+		),
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+func (c *CycleCollector) handleBlock(block *tezos.Block) {
+	if !block.HasMetadata() {
+		return
+	}
+
+	level := block.Metadata.CurrentLevel()
+	c.position.Set(float64(level.CyclePosition))
+	if constants := c.follower.Constants(); constants != nil && constants.BlocksPerCycle != 0 {
+		c.progress.Set(float64(level.CyclePosition+1) / float64(constants.BlocksPerCycle))
+	}
+
+	cycle := level.Cycle
+	evicted, ok := c.window.add(cycle)
+	for _, e := range evicted {
+		label := strconv.Itoa(e)
+		c.blocks.DeleteLabelValues(label)
+		c.fees.DeleteLabelValues(label)
+		c.denunciations.DeleteLabelValues(label)
+	}
+	if !ok || !c.counted.next(level.Level) {
+		return
+	}
+	label := strconv.Itoa(cycle)
+
+	c.blocks.WithLabelValues(label).Inc()
+
+	var fees float64
+	var denunciations int
+	for _, pass := range block.Operations {
+		for _, op := range pass {
+			for _, elem := range op.Contents {
+				if f, ok := elem.(tezos.OperationWithFee); ok {
+					if fee := f.OperationFee(); fee != nil {
+						v, _ := new(big.Float).SetInt(fee).Float64()
+						fees += v
+					}
+				}
+				if _, ok := denunciationKinds[elem.OperationElemKind()]; ok {
+					denunciations++
+				}
+			}
+		}
+	}
+	c.fees.WithLabelValues(label).Add(fees)
+	c.denunciations.WithLabelValues(label).Add(float64(denunciations))
+}
+
+// Describe implements prometheus.Collector
+func (c *CycleCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.position.Describe(ch)
+	c.progress.Describe(ch)
+	c.blocks.Describe(ch)
+	c.fees.Describe(ch)
+	c.denunciations.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *CycleCollector) Collect(ch chan<- prometheus.Metric) {
+	c.position.Collect(ch)
+	c.progress.Collect(ch)
+	c.blocks.Collect(ch)
+	c.fees.Collect(ch)
+	c.denunciations.Collect(ch)
+}
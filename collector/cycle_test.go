@@ -0,0 +1,87 @@
+package collector
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/stretchr/testify/require"
+)
+
+func cycleBlock(t *testing.T, cycle, position int, operations string) *tezos.Block {
+	var block tezos.Block
+	err := json.Unmarshal([]byte(`{
+		"metadata": {
+			"protocol": "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ",
+			"level_info": {"level": `+strconv.Itoa(cycle*8+position)+`, "cycle": `+strconv.Itoa(cycle)+`, "cycle_position": `+strconv.Itoa(position)+`}
+		},
+		"operations": `+operations+`
+	}`), &block)
+	require.NoError(t, err)
+	return &block
+}
+
+func TestCycleCollector(t *testing.T) {
+	c := NewCycleCollector(newTestFollower(t, &tezos.Constants{BlocksPerCycle: 8}), 2)
+
+	c.handleBlock(cycleBlock(t, 10, 6, `[[], [], [{"contents": [{"kind": "double_baking_evidence"}]}], [{"contents": [{"kind": "transaction", "fee": "1000"}, {"kind": "reveal", "fee": "300"}]}]]`))
+	c.handleBlock(cycleBlock(t, 10, 7, `[[], [], [], [{"contents": [{"kind": "transaction", "fee": "200"}]}]]`))
+	c.handleBlock(cycleBlock(t, 11, 0, `[]`))
+	// Blocks without metadata are ignored
+	c.handleBlock(&tezos.Block{})
+
+	requireMetrics(t, c, `
+# HELP tezos_node_cycle_blocks_total The total number of head blocks observed during the cycle.
+# TYPE tezos_node_cycle_blocks_total counter
+tezos_node_cycle_blocks_total{cycle="10"} 2
+tezos_node_cycle_blocks_total{cycle="11"} 1
+# HELP tezos_node_cycle_denunciations_total The total number of double signing evidence operations included into head blocks during the cycle.
+# TYPE tezos_node_cycle_denunciations_total counter
+tezos_node_cycle_denunciations_total{cycle="10"} 1
+tezos_node_cycle_denunciations_total{cycle="11"} 0
+# HELP tezos_node_cycle_fees_mutez_total The total amount of operation fees included into head blocks during the cycle.
+# TYPE tezos_node_cycle_fees_mutez_total counter
+tezos_node_cycle_fees_mutez_total{cycle="10"} 1500
+tezos_node_cycle_fees_mutez_total{cycle="11"} 0
+# HELP tezos_node_cycle_position The position of the head block within its cycle, starting from zero.
+# TYPE tezos_node_cycle_position gauge
+tezos_node_cycle_position 0
+# HELP tezos_node_cycle_progress_ratio The part of the cycle completed by the head block, see also tezos_node_constants_blocks_per_cycle.
+# TYPE tezos_node_cycle_progress_ratio gauge
+tezos_node_cycle_progress_ratio 0.125
+`)
+
+	// The oldest cycle is dropped out of the window and blocks of older cycles are ignored
+	c.handleBlock(cycleBlock(t, 12, 0, `[]`))
+	c.handleBlock(cycleBlock(t, 9, 7, `[]`))
+	requireMetrics(t, c, `
+# HELP tezos_node_cycle_blocks_total The total number of head blocks observed during the cycle.
+# TYPE tezos_node_cycle_blocks_total counter
+tezos_node_cycle_blocks_total{cycle="11"} 1
+tezos_node_cycle_blocks_total{cycle="12"} 1
+`, "tezos_node_cycle_blocks_total")
+}
+
+func TestCycleCollectorOncePerLevel(t *testing.T) {
+	c := NewCycleCollector(newTestFollower(t, &tezos.Constants{BlocksPerCycle: 8}), 2)
+
+	block := cycleBlock(t, 10, 6, `[[], [], [{"contents": [{"kind": "double_baking_evidence"}]}], [{"contents": [{"kind": "transaction", "fee": "1000"}]}]]`)
+	c.handleBlock(block)
+	// The same head replayed on reconnect
+	c.handleBlock(block)
+	// The head replaced by a higher round
+	c.handleBlock(cycleBlock(t, 10, 6, `[[], [], [], [{"contents": [{"kind": "transaction", "fee": "200"}]}]]`))
+
+	requireMetrics(t, c, `
+# HELP tezos_node_cycle_blocks_total The total number of head blocks observed during the cycle.
+# TYPE tezos_node_cycle_blocks_total counter
+tezos_node_cycle_blocks_total{cycle="10"} 1
+# HELP tezos_node_cycle_denunciations_total The total number of double signing evidence operations included into head blocks during the cycle.
+# TYPE tezos_node_cycle_denunciations_total counter
+tezos_node_cycle_denunciations_total{cycle="10"} 1
+# HELP tezos_node_cycle_fees_mutez_total The total amount of operation fees included into head blocks during the cycle.
+# TYPE tezos_node_cycle_fees_mutez_total counter
+tezos_node_cycle_fees_mutez_total{cycle="10"} 1000
+`, "tezos_node_cycle_blocks_total", "tezos_node_cycle_denunciations_total", "tezos_node_cycle_fees_mutez_total")
+}
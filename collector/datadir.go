@@ -0,0 +1,150 @@
+package collector
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	fsSizeDesc = prometheus.NewDesc(
+		"tezos_node_filesystem_size_bytes",
+		"Size of the filesystem holding the node's data directory component.",
+		[]string{"component"},
+		nil)
+
+	fsAvailDesc = prometheus.NewDesc(
+		"tezos_node_filesystem_avail_bytes",
+		"Space available to unprivileged users on the filesystem holding the node's data directory component.",
+		[]string{"component"},
+		nil)
+
+	storageBytesDesc = prometheus.NewDesc(
+		"tezos_node_storage_bytes",
+		"Total size of files in the node's data directory component.",
+		[]string{"component"},
+		nil)
+
+	fsErrorDesc = prometheus.NewDesc(
+		"tezos_node_filesystem_error",
+		"A gauge that is set to 1 when the filesystem or storage stats of the data directory component can't be obtained, 0 otherwise.",
+		[]string{"component"},
+		nil)
+)
+
+var dataDirComponents = []string{"store", "context"}
+
+// dirSize returns the total size of regular files under path. Entries removed by the node during the walk are skipped.
+func dirSize(path string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p != path && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			size += uint64(info.Size())
+		}
+		return nil
+	})
+	return size, err
+}
+
+type fsStats struct {
+	size  uint64
+	avail uint64
+}
+
+type storageSize struct {
+	size uint64
+	err  error
+}
+
+// DataDirCollector collects filesystem and storage usage of a co-located node's data directory
+type DataDirCollector struct {
+	dataDir  string
+	interval time.Duration
+
+	mtx   sync.Mutex
+	sizes map[string]*storageSize
+}
+
+// NewDataDirCollector returns a new DataDirCollector. Walking the data directory is slow so the storage size is computed
+// in background every interval and the last result is reported. It isn't reported until the first walk is done.
+func NewDataDirCollector(dataDir string, interval time.Duration) *DataDirCollector {
+	c := &DataDirCollector{
+		dataDir:  dataDir,
+		interval: interval,
+		sizes:    make(map[string]*storageSize, len(dataDirComponents)),
+	}
+
+	go c.sizePollLoop()
+	return c
+}
+
+func (c *DataDirCollector) sizePollLoop() {
+	t := time.NewTicker(c.interval)
+
+	for {
+		for _, component := range dataDirComponents {
+			size, err := dirSize(filepath.Join(c.dataDir, component))
+			if err != nil {
+				log.WithError(err).WithField("component", component).Error("error getting storage size")
+			}
+			c.mtx.Lock()
+			c.sizes[component] = &storageSize{size: size, err: err}
+			c.mtx.Unlock()
+		}
+		<-t.C
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *DataDirCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- fsSizeDesc
+	ch <- fsAvailDesc
+	ch <- storageBytesDesc
+	ch <- fsErrorDesc
+}
+
+// Collect implements prometheus.Collector and is called by the Prometheus registry when collecting metrics.
+func (c *DataDirCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, component := range dataDirComponents {
+		path := filepath.Join(c.dataDir, component)
+		stats, err := statFS(path)
+		var val float64
+		if err != nil {
+			log.WithError(err).WithField("component", component).Error("error getting filesystem stats")
+			val = 1
+		} else {
+			ch <- prometheus.MustNewConstMetric(fsSizeDesc, prometheus.GaugeValue, float64(stats.size), component)
+			ch <- prometheus.MustNewConstMetric(fsAvailDesc, prometheus.GaugeValue, float64(stats.avail), component)
+		}
+
+		c.mtx.Lock()
+		size := c.sizes[component]
+		c.mtx.Unlock()
+		if size != nil {
+			if size.err != nil {
+				val = 1
+			} else {
+				ch <- prometheus.MustNewConstMetric(storageBytesDesc, prometheus.GaugeValue, float64(size.size), component)
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(fsErrorDesc, prometheus.GaugeValue, val, component)
+	}
+}
@@ -0,0 +1,44 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataDirCollector(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "store", "cycles"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "store", "cycles", "0"), make([]byte, 100), 0o644))
+
+	c := NewDataDirCollector(dir, 10*time.Millisecond)
+
+	// The missing context directory is reported as an error
+	requireMetrics(t, c, `
+# HELP tezos_node_filesystem_error A gauge that is set to 1 when the filesystem or storage stats of the data directory component can't be obtained, 0 otherwise.
+# TYPE tezos_node_filesystem_error gauge
+tezos_node_filesystem_error{component="context"} 1
+tezos_node_filesystem_error{component="store"} 0
+# HELP tezos_node_storage_bytes Total size of files in the node's data directory component.
+# TYPE tezos_node_storage_bytes gauge
+tezos_node_storage_bytes{component="store"} 100
+`, "tezos_node_storage_bytes", "tezos_node_filesystem_error")
+
+	// The size is updated in background
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "context"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "context", "index"), make([]byte, 50), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "store", "cycles", "1"), make([]byte, 20), 0o644))
+	requireMetrics(t, c, `
+# HELP tezos_node_filesystem_error A gauge that is set to 1 when the filesystem or storage stats of the data directory component can't be obtained, 0 otherwise.
+# TYPE tezos_node_filesystem_error gauge
+tezos_node_filesystem_error{component="context"} 0
+tezos_node_filesystem_error{component="store"} 0
+# HELP tezos_node_storage_bytes Total size of files in the node's data directory component.
+# TYPE tezos_node_storage_bytes gauge
+tezos_node_storage_bytes{component="context"} 50
+tezos_node_storage_bytes{component="store"} 120
+`, "tezos_node_storage_bytes", "tezos_node_filesystem_error")
+}
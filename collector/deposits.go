@@ -0,0 +1,106 @@
+package collector
+
+import (
+	"fmt"
+	"math/big"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MaxDepositAddresses is the maximum number of addresses watched by DepositCollector
+const MaxDepositAddresses = 1000
+
+// depositSeenSize is the number of last deposit operation hashes remembered to avoid counting them more than once
+const depositSeenSize = 1000
+
+// DepositCollector counts applied incoming transactions to the watched addresses included into head blocks.
+// Operations included again by a replayed or replacing head are counted once.
+type DepositCollector struct {
+	addresses map[string]struct{}
+	count     *prometheus.CounterVec
+	amount    *prometheus.CounterVec
+	seen      *seenSet
+}
+
+// NewDepositCollector returns a new DepositCollector fed by the block follower.
+func NewDepositCollector(follower *BlockFollower, addresses []string) (*DepositCollector, error) {
+	if len(addresses) > MaxDepositAddresses {
+		return nil, fmt.Errorf("too many deposit addresses: %d (max %d)", len(addresses), MaxDepositAddresses)
+	}
+
+	c := &DepositCollector{
+		addresses: make(map[string]struct{}, len(addresses)),
+		count: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "deposits",
+				Name:      "total",
+				Help:      "The total number of applied incoming transactions to the address included into head blocks.",
+			},
+			[]string{"address"},
+		),
+		amount: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "deposits",
+				Name:      "mutez_total",
+				Help:      "The total amount received by the address with applied transactions included into head blocks.",
+			},
+			[]string{"address"},
+		),
+		seen: newSeenSet(depositSeenSize),
+	}
+
+	for _, addr := range addresses {
+		c.addresses[addr] = struct{}{}
+		// Initialize to get zeroes before the first deposit
+		c.count.WithLabelValues(addr)
+		c.amount.WithLabelValues(addr)
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c, nil
+}
+
+func (c *DepositCollector) handleBlock(block *tezos.Block) {
+	if !block.HasMetadata() {
+		return
+	}
+	for _, pass := range block.Operations {
+		for _, op := range pass {
+			var deposits []*tezos.TransactionOperationElem
+			for _, elem := range op.Contents {
+				tx, ok := elem.(*tezos.TransactionOperationElem)
+				if !ok || tx.Metadata.OperationResult.Status != "applied" {
+					continue
+				}
+				if _, ok := c.addresses[tx.Destination]; ok {
+					deposits = append(deposits, tx)
+				}
+			}
+			if len(deposits) == 0 || !c.seen.add(op.Hash) {
+				continue
+			}
+			for _, tx := range deposits {
+				c.count.WithLabelValues(tx.Destination).Inc()
+				if tx.Amount != nil {
+					v, _ := new(big.Float).SetInt(&tx.Amount.Int).Float64()
+					c.amount.WithLabelValues(tx.Destination).Add(v)
+				}
+			}
+		}
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *DepositCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.count.Describe(ch)
+	c.amount.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *DepositCollector) Collect(ch chan<- prometheus.Metric) {
+	c.count.Collect(ch)
+	c.amount.Collect(ch)
+}
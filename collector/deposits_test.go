@@ -0,0 +1,81 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/stretchr/testify/require"
+)
+
+func depositTx(destination, amount, status string) string {
+	return fmt.Sprintf(`{"kind": "transaction", "destination": %q, "amount": %q, "metadata": {"operation_result": {"status": %q}}}`, destination, amount, status)
+}
+
+func TestDepositCollector(t *testing.T) {
+	c, err := NewDepositCollector(newTestFollower(t, nil), []string{testBaker, testOther})
+	require.NoError(t, err)
+
+	var block tezos.Block
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"metadata": {"protocol": "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ"},
+		"operations": [[], [], [], [
+			{"hash": "op1", "contents": [`+depositTx(testBaker, "1000", "applied")+`, `+depositTx(testBaker, "500", "applied")+`]},
+			{"hash": "op2", "contents": [`+depositTx(testBaker, "700", "backtracked")+`]},
+			{"hash": "op3", "contents": [`+depositTx("tz1burnburnburnburnburnburnburjAYjjX", "300", "applied")+`]}
+		]]
+	}`), &block))
+	c.handleBlock(&block)
+	// Blocks without metadata are ignored
+	block.Metadata.Protocol = ""
+	c.handleBlock(&block)
+
+	requireMetrics(t, c, `
+# HELP tezos_node_deposits_mutez_total The total amount received by the address with applied transactions included into head blocks.
+# TYPE tezos_node_deposits_mutez_total counter
+tezos_node_deposits_mutez_total{address="`+testBaker+`"} 1500
+tezos_node_deposits_mutez_total{address="`+testOther+`"} 0
+# HELP tezos_node_deposits_total The total number of applied incoming transactions to the address included into head blocks.
+# TYPE tezos_node_deposits_total counter
+tezos_node_deposits_total{address="`+testBaker+`"} 2
+tezos_node_deposits_total{address="`+testOther+`"} 0
+`)
+}
+
+func TestDepositCollectorOnce(t *testing.T) {
+	c, err := NewDepositCollector(newTestFollower(t, nil), []string{testBaker})
+	require.NoError(t, err)
+
+	block := func(ops ...string) *tezos.Block {
+		var block tezos.Block
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"metadata": {"protocol": "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ"},
+			"operations": [[], [], [], [`+strings.Join(ops, ", ")+`]]
+		}`), &block))
+		return &block
+	}
+	op1 := `{"hash": "op1", "contents": [` + depositTx(testBaker, "1000", "applied") + `]}`
+	op2 := `{"hash": "op2", "contents": [` + depositTx(testBaker, "200", "applied") + `]}`
+
+	c.handleBlock(block(op1))
+	// The same head replayed on reconnect
+	c.handleBlock(block(op1))
+	// The head replaced by a higher round including the same operation
+	c.handleBlock(block(op1, op2))
+
+	requireMetrics(t, c, `
+# HELP tezos_node_deposits_mutez_total The total amount received by the address with applied transactions included into head blocks.
+# TYPE tezos_node_deposits_mutez_total counter
+tezos_node_deposits_mutez_total{address="`+testBaker+`"} 1200
+# HELP tezos_node_deposits_total The total number of applied incoming transactions to the address included into head blocks.
+# TYPE tezos_node_deposits_total counter
+tezos_node_deposits_total{address="`+testBaker+`"} 2
+`)
+}
+
+func TestDepositCollectorLimit(t *testing.T) {
+	_, err := NewDepositCollector(newTestFollower(t, nil), make([]string, MaxDepositAddresses+1))
+	require.EqualError(t, err, fmt.Sprintf("too many deposit addresses: %d (max %d)", MaxDepositAddresses+1, MaxDepositAddresses))
+}
@@ -0,0 +1,160 @@
+package collector
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtures = "../go-tezos/fixtures/"
+
+func newTestService(t *testing.T, srv *tezostest.Server) *tezos.Service {
+	c, err := tezos.NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	return &tezos.Service{Client: c}
+}
+
+// newTestFollower returns a follower which isn't started with the protocol constants set, blocks are fed to the handlers directly
+func newTestFollower(t *testing.T, constants *tezos.Constants) *BlockFollower {
+	c, err := tezos.NewRPCClient("http://localhost:8732")
+	require.NoError(t, err)
+	follower := NewBlockFollower(&tezos.Service{Client: c}, "main", time.Second, time.Hour, 0, nil, nil)
+	follower.constants = constants
+	return follower
+}
+
+// requireMetrics waits for the collector to report the metrics given in the text exposition format
+func requireMetrics(t *testing.T, c prometheus.Collector, expected string, names ...string) {
+	var err error
+	ok := func() bool {
+		err = testutil.CollectAndCompare(c, strings.NewReader(expected), names...)
+		return err == nil
+	}
+	require.Eventually(t, ok, 5*time.Second, 10*time.Millisecond, "%v", err)
+}
+
+// withRPCFailed describes the shared tezos_rpc_failed descriptor along with the collector's own ones like NetworkCollector does in the exporter
+type withRPCFailed struct {
+	prometheus.Collector
+}
+
+func (c withRPCFailed) Describe(ch chan<- *prometheus.Desc) {
+	c.Collector.Describe(ch)
+	ch <- rpcFailedDesc
+}
+
+func TestE2EBlockFollower(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	heads := tezostest.MustFixture(fixtures + "monitor/heads.chunked")
+	heads.Delay = 50 * time.Millisecond
+	heads.Disconnect = true
+	srv.Handle("/monitor/heads/main", heads)
+	srv.Handle("/chains/main/blocks/*", tezostest.MustFixture(fixtures+"chains/block.json"))
+	srv.Handle("/chains/main/blocks/*/context/constants", tezostest.MustFixture(fixtures+"chains/constants.json"))
+
+	follower := NewBlockFollower(newTestService(t, srv), "main", time.Second, 10*time.Millisecond, 0, nil, nil)
+	protocol := NewProtocolCollector(follower)
+	metadata := NewBlockMetadataCollector(follower)
+	follower.Start(context.Background())
+
+	requireMetrics(t, protocol, `
+# HELP tezos_node_protocol_info Protocol of the current head block.
+# TYPE tezos_node_protocol_info gauge
+tezos_node_protocol_info{next_protocol="PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt",protocol="PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt"} 1
+`, "tezos_node_protocol_info")
+	requireMetrics(t, metadata, `
+# HELP tezos_node_head_block_metadata_available Whether the metadata of the head block was received. Balance updates, rewards, deposits, cycle and protocol metrics aren't updated for blocks without it.
+# TYPE tezos_node_head_block_metadata_available gauge
+tezos_node_head_block_metadata_available 1
+`, "tezos_node_head_block_metadata_available")
+	require.NotNil(t, follower.Constants())
+
+	// The follower reconnects after the stream is dropped
+	require.Eventually(t, func() bool { return srv.Requests("/monitor/heads/main") >= 2 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestE2EBlockFollowerProtocolChange(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	heads := tezostest.MustFixture(fixtures + "monitor/heads.chunked")
+	// The protocol changes with the second head and stays the same with the third one
+	heads.Chunks = append(heads.Chunks,
+		[]byte(`{"hash":"BLcKEv7jKSNCDbTmMUjeXvzCYc3pCbExbKEWbTt1pVWUvcJEjSy","level":390398,"proto":4}`+"\n"),
+		[]byte(`{"hash":"BLsBWYPTVjdtZc1fjHwfc2qqmwWwhNFaEm6SD8GGBUfmHAwEePr","level":390399,"proto":4}`+"\n"),
+	)
+	heads.Hold = true
+	srv.Handle("/monitor/heads/main", heads)
+	srv.Handle("/chains/main/blocks/*", tezostest.MustFixture(fixtures+"chains/block.json"))
+	srv.Handle("/chains/main/blocks/*/context/constants",
+		tezostest.MustFixture(fixtures+"chains/constants.json"),
+		&tezostest.Response{Body: []byte(`{"blocks_per_cycle":24576,"minimal_block_delay":"6"}`)},
+	)
+
+	follower := NewBlockFollower(newTestService(t, srv), "main", time.Second, time.Hour, 0, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	follower.Start(ctx)
+
+	// Constants are fetched before the block so they are up to date once the last block is requested
+	require.Eventually(t, func() bool { return srv.Requests("/chains/main/blocks/*") == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 2, srv.Requests("/chains/main/blocks/*/context/constants"))
+	constants := follower.Constants()
+	require.Equal(t, 24576, constants.BlocksPerCycle)
+	require.Equal(t, int64(6), constants.MinimalBlockDelay)
+}
+
+func TestE2EBlockFollowerMetadataTooLarge(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/monitor/heads/main", tezostest.MustFixture(fixtures+"monitor/heads.chunked"))
+	srv.Handle("/chains/main/blocks/*",
+		&tezostest.Response{Status: 500, ContentType: "text/plain", Body: []byte("Metadata too large")},
+		&tezostest.Response{Body: []byte(`{"protocol":"PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt","hash":"BKq199p1Hm1phfJ4DhuRjB6yBSJnDNG8sgMSnja9pXR96T2Hyy1"}`)},
+	)
+	srv.Handle("/chains/main/blocks/*/context/constants", tezostest.MustFixture(fixtures+"chains/constants.json"))
+
+	follower := NewBlockFollower(newTestService(t, srv), "main", time.Second, time.Hour, 0, nil, nil)
+	metadata := NewBlockMetadataCollector(follower)
+	follower.Start(context.Background())
+
+	requireMetrics(t, metadata, `
+# HELP tezos_exporter_block_metadata_downgrades_total The total number of head blocks fetched without metadata after the node timed out or refused to return too large metadata.
+# TYPE tezos_exporter_block_metadata_downgrades_total counter
+tezos_exporter_block_metadata_downgrades_total 1
+# HELP tezos_node_head_block_metadata_available Whether the metadata of the head block was received. Balance updates, rewards, deposits, cycle and protocol metrics aren't updated for blocks without it.
+# TYPE tezos_node_head_block_metadata_available gauge
+tezos_node_head_block_metadata_available 0
+`)
+}
+
+func TestE2EMempoolMalformedChunk(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	broken := tezostest.MustFixture(fixtures + "monitor/mempool_operations.chunked")
+	broken.Chunks = append(broken.Chunks, []byte(`[{"protocol":`))
+	held := tezostest.MustFixture(fixtures + "monitor/mempool_operations.chunked")
+	held.Hold = true
+	srv.Handle("/chains/main/mempool/monitor_operations", broken, held)
+
+	mempool := NewMempoolOperationsCollectorCollector(context.Background(), newTestService(t, srv), "main", []string{"applied"}, 10*time.Millisecond, false, false, nil, nil, nil)
+
+	// Operations received before the malformed chunk are counted, the monitor reconnects and keeps counting
+	requireMetrics(t, mempool, `
+# HELP tezos_node_mempool_operations_total The total number of mempool operations.
+# TYPE tezos_node_mempool_operations_total counter
+tezos_node_mempool_operations_total{kind="endorsement",pool="applied",proto="Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp9FeuAXJ4eV9otd"} 4
+`, "tezos_node_mempool_operations_total")
+	require.Equal(t, 2, srv.Requests("/chains/main/mempool/monitor_operations"))
+}
@@ -0,0 +1,75 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	earliestLevelDesc = prometheus.NewDesc(
+		"tezos_node_earliest_block_level",
+		"Level of the earliest block available on the node: the savepoint is the lowest block with metadata and context, the caboose is the lowest known block.",
+		[]string{"kind"},
+		nil)
+
+	earliestTimestampDesc = prometheus.NewDesc(
+		"tezos_node_earliest_block_timestamp_seconds",
+		"Timestamp of the earliest block available on the node.",
+		[]string{"kind"},
+		nil)
+)
+
+// EarliestBlockCollector reports how far back the node's history goes
+type EarliestBlockCollector struct {
+	service *tezos.Service
+	timeout time.Duration
+	chainID string
+}
+
+// NewEarliestBlockCollector returns a new EarliestBlockCollector.
+func NewEarliestBlockCollector(service *tezos.Service, timeout time.Duration, chainID string) *EarliestBlockCollector {
+	return &EarliestBlockCollector{
+		service: service,
+		timeout: timeout,
+		chainID: chainID,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *EarliestBlockCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- earliestLevelDesc
+	ch <- earliestTimestampDesc
+}
+
+// Collect implements prometheus.Collector and is called by the Prometheus registry when collecting metrics.
+func (c *EarliestBlockCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, kind := range []struct {
+		name string
+		get  func(ctx context.Context, chainID string) (*tezos.BlockLevel, error)
+	}{
+		{"savepoint", c.service.GetSavepoint},
+		{"caboose", c.service.GetCaboose},
+	} {
+		level, err := kind.get(ctx, c.chainID)
+		var val float64
+		if err != nil {
+			log.WithError(err).WithField("kind", kind.name).Error("error getting earliest block")
+			val = 1
+		} else {
+			ch <- prometheus.MustNewConstMetric(earliestLevelDesc, prometheus.GaugeValue, float64(level.Level), kind.name)
+			if header, err := c.service.GetBlockHeader(ctx, c.chainID, level.BlockHash); err == nil {
+				ch <- prometheus.MustNewConstMetric(earliestTimestampDesc, prometheus.GaugeValue, float64(header.Timestamp.Unix()), kind.name)
+			} else {
+				log.WithError(err).WithField("kind", kind.name).Error("error getting earliest block header")
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/chains/"+c.chainID+"/levels/"+kind.name)
+	}
+}
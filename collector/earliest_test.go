@@ -0,0 +1,46 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+)
+
+func TestEarliestBlockCollector(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/chains/main/levels/savepoint", &tezostest.Response{Body: []byte(`{"block_hash":"BLnoArJNPCyYFK2z3Mnomi36Jo3FwrjriJ6hvzgTJGYYDKEkDXm","level":219133}`)})
+	srv.Handle("/chains/main/blocks/BLnoArJNPCyYFK2z3Mnomi36Jo3FwrjriJ6hvzgTJGYYDKEkDXm/header", tezostest.MustFixture(fixtures+"chains/header.json"))
+	// The caboose header can't be fetched
+	srv.Handle("/chains/main/levels/caboose", &tezostest.Response{Body: []byte(`{"block_hash":"BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2","level":0}`)})
+
+	c := NewEarliestBlockCollector(newTestService(t, srv), time.Second, "main")
+	requireMetrics(t, withRPCFailed{c}, `
+# HELP tezos_node_earliest_block_level Level of the earliest block available on the node: the savepoint is the lowest block with metadata and context, the caboose is the lowest known block.
+# TYPE tezos_node_earliest_block_level gauge
+tezos_node_earliest_block_level{kind="caboose"} 0
+tezos_node_earliest_block_level{kind="savepoint"} 219133
+# HELP tezos_node_earliest_block_timestamp_seconds Timestamp of the earliest block available on the node.
+# TYPE tezos_node_earliest_block_timestamp_seconds gauge
+tezos_node_earliest_block_timestamp_seconds{kind="savepoint"} 1.543340997e+09
+# HELP tezos_rpc_failed A gauge that is set to 1 when a metrics collection RPC failed during the current scrape, 0 otherwise.
+# TYPE tezos_rpc_failed gauge
+tezos_rpc_failed{rpc="/chains/main/levels/caboose"} 0
+tezos_rpc_failed{rpc="/chains/main/levels/savepoint"} 0
+`)
+}
+
+func TestEarliestBlockCollectorFailed(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	c := NewEarliestBlockCollector(newTestService(t, srv), time.Second, "main")
+	requireMetrics(t, withRPCFailed{c}, `
+# HELP tezos_rpc_failed A gauge that is set to 1 when a metrics collection RPC failed during the current scrape, 0 otherwise.
+# TYPE tezos_rpc_failed gauge
+tezos_rpc_failed{rpc="/chains/main/levels/caboose"} 1
+tezos_rpc_failed{rpc="/chains/main/levels/savepoint"} 1
+`)
+}
@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"strings"
+)
+
+// endpointParams maps path segments to the placeholders substituted for the segments following them
+var endpointParams = map[string]string{
+	"blocks":           "<block_id>",
+	"peers":            "<peer_id>",
+	"points":           "<point>",
+	"delegates":        "<pkh>",
+	"contracts":        "<contract_id>",
+	"protocols":        "<protocol_hash>",
+	"operations":       "<n>",
+	"heads":            "<chain_id>",
+	"prevalidators":    "<chain_id>",
+	"chain_validators": "<chain_id>",
+}
+
+// rpcEndpoint returns the RPC path with block ids, peer ids and other variable segments replaced
+// by placeholders to keep label cardinality low, e.g. /chains/main/blocks/<block_id>/header
+func rpcEndpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if p, ok := endpointParams[segments[i-1]]; ok && segments[i] != "" {
+			segments[i] = p
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// rpcEndpointName returns a short name of the RPC with the chain id and variable segments dropped,
+// e.g. network_peers for /network/peers or blocks_header for /chains/main/blocks/head/header
+func rpcEndpointName(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var parts []string
+	for i := 0; i < len(segments); i++ {
+		switch s := segments[i]; {
+		case s == "chains":
+			// Skip the chain id
+			i++
+		case i > 0 && endpointParams[segments[i-1]] != "":
+		default:
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "_")
+}
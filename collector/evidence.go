@@ -0,0 +1,84 @@
+package collector
+
+import (
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// evidenceSeenSize is the number of last mempool evidence operation hashes remembered to avoid counting them more than once
+const evidenceSeenSize = 1000
+
+// EvidenceCollector counts double signing evidence operations seen in head blocks and the mempool
+type EvidenceCollector struct {
+	bakers  map[string]struct{}
+	counter *prometheus.CounterVec
+	seen    *seenSet
+}
+
+// NewEvidenceCollector returns a new EvidenceCollector fed by the block follower and the mempool collector.
+// Accused delegates are reported by address only if listed in bakers.
+func NewEvidenceCollector(follower *BlockFollower, mempool *MempoolOperationsCollector, bakers []string) *EvidenceCollector {
+	c := &EvidenceCollector{
+		bakers: make(map[string]struct{}, len(bakers)),
+		counter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Name:      "double_signing_evidence_total",
+				Help:      "The total number of double signing evidence operations by the source (block or mempool) and the accused delegate if watched, 'other' if not watched or 'unknown' if not known yet.",
+			},
+			[]string{"kind", "source", "delegate"},
+		),
+		seen: newSeenSet(evidenceSeenSize),
+	}
+	for _, b := range bakers {
+		c.bakers[b] = struct{}{}
+	}
+
+	follower.Subscribe(c.handleBlock)
+	mempool.Subscribe(c.handleMempool)
+	return c
+}
+
+func (c *EvidenceCollector) delegate(elem tezos.OperationElem) string {
+	d := elem.(tezos.DenunciationOperation).AccusedDelegate()
+	if d == "" {
+		return "unknown"
+	}
+	if _, ok := c.bakers[d]; ok {
+		return d
+	}
+	return "other"
+}
+
+func (c *EvidenceCollector) handleBlock(block *tezos.Block) {
+	for _, pass := range block.Operations {
+		for _, op := range pass {
+			for _, elem := range op.Contents {
+				if _, ok := elem.(tezos.DenunciationOperation); ok {
+					c.counter.WithLabelValues(elem.OperationElemKind(), "block", c.delegate(elem)).Inc()
+				}
+			}
+		}
+	}
+}
+
+func (c *EvidenceCollector) handleMempool(pool string, ops []*tezos.Operation) {
+	for _, op := range ops {
+		for _, elem := range op.Contents {
+			if _, ok := elem.(tezos.DenunciationOperation); ok && c.seen.add(op.Hash) {
+				// Mempool operations come without metadata
+				c.counter.WithLabelValues(elem.OperationElemKind(), "mempool", c.delegate(elem)).Inc()
+			}
+		}
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *EvidenceCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.counter.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *EvidenceCollector) Collect(ch chan<- prometheus.Metric) {
+	c.counter.Collect(ch)
+}
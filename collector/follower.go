@@ -0,0 +1,354 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+type blockBodyKey struct{}
+
+// blockBody receives the block response measurements
+type blockBody struct {
+	size int64
+	raw  *bytes.Buffer // the response contents are kept if not nil
+}
+
+// countingReader counts bytes read from the response body
+type countingReader struct {
+	io.ReadCloser
+	body *blockBody
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.body.size += int64(n)
+	if r.body.raw != nil {
+		r.body.raw.Write(p[:n])
+	}
+	return n, err
+}
+
+// operationSizes returns the lengths of the operations JSON data in the block response by validation pass
+func operationSizes(raw []byte) ([][]int, error) {
+	var tmp struct {
+		Operations [][]json.RawMessage `json:"operations"`
+	}
+	if err := json.Unmarshal(raw, &tmp); err != nil {
+		return nil, err
+	}
+	sizes := make([][]int, len(tmp.Operations))
+	for i, pass := range tmp.Operations {
+		sizes[i] = make([]int, len(pass))
+		for j, op := range pass {
+			sizes[i][j] = len(op)
+		}
+	}
+	return sizes, nil
+}
+
+// BlockHandler is called for every new head block
+type BlockHandler func(block *tezos.Block)
+
+// BlockSizeHandler is called for every new head block along with the size of the block RPC response in bytes
+// and the lengths of the operations JSON data by validation pass as they were received from the node
+type BlockSizeHandler func(block *tezos.Block, size int64, opSizes [][]int)
+
+// HeadHandler is called for every new head as soon as it arrives, before the full block is fetched
+type HeadHandler func(head *tezos.BlockInfo)
+
+// BlockFollower monitors the chain head and delivers full head blocks to subscribed handlers
+type BlockFollower struct {
+	downgrades uint64 // accessed atomically, kept first for 64-bit alignment
+
+	service  *tezos.Service
+	chainID  string
+	timeout  time.Duration
+	interval time.Duration
+	poll     time.Duration
+	guard    *MemoryGuard
+	opts     *tezos.BlockOptions
+
+	mtx          sync.RWMutex
+	handlers     []BlockHandler
+	sizeHandlers []BlockSizeHandler
+	headHandlers []HeadHandler
+	caps         *tezos.ProtocolCapabilities
+	constants    *tezos.Constants
+	constProto   int
+}
+
+// NewBlockFollower returns a new BlockFollower. Blocks are skipped while the guard limit is exceeded.
+// Non zero poll interval makes the follower poll the head header instead of using the heads monitor stream
+// which may be broken by RPC proxies not supporting chunked responses. Blocks are requested with opts if not nil,
+// handlers must expect blocks without metadata if it's disabled there.
+func NewBlockFollower(service *tezos.Service, chainID string, timeout, interval, poll time.Duration, guard *MemoryGuard, opts *tezos.BlockOptions) *BlockFollower {
+	// Block responses are measured using a blockBody passed in the request context
+	client := *service.Client
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	client.Transport = promhttp.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		resp, err := transport.RoundTrip(r)
+		if body, ok := r.Context().Value(blockBodyKey{}).(*blockBody); ok && err == nil {
+			resp.Body = &countingReader{ReadCloser: resp.Body, body: body}
+		}
+		return resp, err
+	})
+
+	srv := *service
+	srv.Client = &client
+
+	return &BlockFollower{
+		service:  &srv,
+		chainID:  chainID,
+		timeout:  timeout,
+		interval: interval,
+		poll:     poll,
+		guard:    guard,
+		opts:     opts,
+	}
+}
+
+// Subscribe adds a handler to be called for every new head block
+func (f *BlockFollower) Subscribe(h BlockHandler) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.handlers = append(f.handlers, h)
+}
+
+// SubscribeBlockSize adds a handler to be called for every new head block along with its size
+func (f *BlockFollower) SubscribeBlockSize(h BlockSizeHandler) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.sizeHandlers = append(f.sizeHandlers, h)
+}
+
+// SubscribeHeads adds a handler to be called for every new head
+func (f *BlockFollower) SubscribeHeads(h HeadHandler) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.headHandlers = append(f.headHandlers, h)
+}
+
+// HeadEndpoint returns the RPC path the heads are received from
+func (f *BlockFollower) HeadEndpoint() string {
+	if f.poll != 0 {
+		return "/chains/" + f.chainID + "/blocks/head/header"
+	}
+	return "/monitor/heads/" + f.chainID
+}
+
+// MetadataDowngrades returns the number of blocks fetched without metadata after the node failed to return them in full
+func (f *BlockFollower) MetadataDowngrades() uint64 {
+	return atomic.LoadUint64(&f.downgrades)
+}
+
+// Capabilities returns capabilities of the latest head protocol. Unless the first block is received, the newest known protocol is assumed.
+func (f *BlockFollower) Capabilities() *tezos.ProtocolCapabilities {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+	if f.caps == nil {
+		return tezos.GetProtocolCapabilities("")
+	}
+	return f.caps
+}
+
+// Constants returns constants of the latest head protocol or nil if they aren't known yet
+func (f *BlockFollower) Constants() *tezos.Constants {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+	return f.constants
+}
+
+// updateConstants fetches protocol constants on protocol change. The protocol is taken from the head
+// so the constants are refreshed at activation even if the block itself is skipped. On error the next head retries.
+func (f *BlockFollower) updateConstants(head *tezos.BlockInfo) {
+	f.mtx.RLock()
+	known := f.constants != nil
+	ok := known && f.constProto == head.Proto
+	prev := f.constProto
+	f.mtx.RUnlock()
+	if ok {
+		return
+	}
+
+	if known {
+		log.WithFields(log.Fields{"block": head.Hash, "proto": head.Proto, "previous_proto": prev}).
+			Info("protocol activation detected, refreshing constants")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), f.timeout)
+	defer cancel()
+
+	constants, err := f.service.GetConstants(ctx, f.chainID, head.Hash)
+	if err != nil {
+		log.WithError(err).WithField("block", head.Hash).Error("error getting protocol constants")
+		return
+	}
+
+	f.mtx.Lock()
+	f.constants = constants
+	f.constProto = head.Proto
+	f.mtx.Unlock()
+}
+
+// Start starts following the chain head until the context is done
+func (f *BlockFollower) Start(ctx context.Context) {
+	log.WithField("chain", f.chainID).Info("starting block follower")
+	if f.poll != 0 {
+		go f.poller(ctx)
+	} else {
+		go f.listener(ctx)
+	}
+}
+
+func (f *BlockFollower) fetchBlock(hash string, opts *tezos.BlockOptions, body *blockBody) (*tezos.Block, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), f.timeout)
+	defer cancel()
+
+	body.size = 0
+	if body.raw != nil {
+		body.raw.Reset()
+	}
+	return f.service.GetBlockWithOptions(context.WithValue(ctx, blockBodyKey{}, body), f.chainID, hash, opts)
+}
+
+// getBlock fetches the block. If the node times out or refuses to return too large metadata, the block is fetched
+// once again without metadata so the metrics not depending on it are still updated.
+func (f *BlockFollower) getBlock(hash string, body *blockBody) (*tezos.Block, error) {
+	block, err := f.fetchBlock(hash, f.opts, body)
+	if err == nil || (f.opts != nil && f.opts.Metadata == tezos.MetadataNever) ||
+		(!tezos.IsMetadataTooLarge(err) && !errors.Is(err, context.DeadlineExceeded)) {
+		return block, err
+	}
+
+	log.WithError(err).WithField("block", hash).Warn("error getting block, retrying without metadata")
+	block, err = f.fetchBlock(hash, &tezos.BlockOptions{Metadata: tezos.MetadataNever}, body)
+	if err == nil {
+		atomic.AddUint64(&f.downgrades, 1)
+	}
+	return block, err
+}
+
+func (f *BlockFollower) handleHead(head *tezos.BlockInfo) {
+	f.mtx.RLock()
+	headHandlers := f.headHandlers
+	f.mtx.RUnlock()
+
+	for _, h := range headHandlers {
+		h(head)
+	}
+
+	f.updateConstants(head)
+
+	if f.guard.Exceeded() {
+		log.WithField("block", head.Hash).Warn("soft memory limit exceeded, skipping block")
+		return
+	}
+
+	// The raw response is only kept for the operation sizes
+	var body blockBody
+	f.mtx.RLock()
+	if len(f.sizeHandlers) != 0 {
+		body.raw = new(bytes.Buffer)
+	}
+	f.mtx.RUnlock()
+
+	block, err := f.getBlock(head.Hash, &body)
+	if err != nil {
+		log.WithError(err).WithField("block", head.Hash).Error("error getting block")
+		return
+	}
+
+	var opSizes [][]int
+	if body.raw != nil {
+		if opSizes, err = operationSizes(body.raw.Bytes()); err != nil {
+			log.WithError(err).WithField("block", head.Hash).Warn("error measuring block operations")
+		}
+	}
+
+	f.mtx.Lock()
+	if f.caps == nil || f.caps.Protocol != block.Protocol {
+		f.caps = tezos.GetProtocolCapabilities(block.Protocol)
+	}
+	handlers := f.handlers
+	sizeHandlers := f.sizeHandlers
+	f.mtx.Unlock()
+
+	for _, h := range handlers {
+		h(block)
+	}
+	for _, h := range sizeHandlers {
+		h(block, body.size, opSizes)
+	}
+}
+
+func (f *BlockFollower) listener(ctx context.Context) {
+	ch := make(chan *tezos.BlockInfo, 100)
+	defer close(ch)
+
+	go func() {
+		for head := range ch {
+			f.handleHead(head)
+		}
+	}()
+
+	for ctx.Err() == nil {
+		err := f.service.MonitorHeads(ctx, f.chainID, ch)
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).Error("error monitoring heads")
+			select {
+			case <-time.After(f.interval):
+			case <-ctx.Done():
+			}
+		}
+	}
+}
+
+func (f *BlockFollower) getHead(ctx context.Context) (*tezos.BlockHeader, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+	return f.service.GetBlockHeader(ctx, f.chainID, "head")
+}
+
+func (f *BlockFollower) poller(ctx context.Context) {
+	var last string
+	for ctx.Err() == nil {
+		header, err := f.getHead(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.WithError(err).Error("error polling chain head")
+		} else if header.Hash != last {
+			last = header.Hash
+			f.handleHead(&tezos.BlockInfo{
+				Hash:           header.Hash,
+				Level:          header.Level,
+				Proto:          header.Proto,
+				Predecessor:    header.Predecessor,
+				Timestamp:      header.Timestamp,
+				ValidationPass: header.ValidationPass,
+				OperationsHash: header.OperationsHash,
+				Fitness:        header.Fitness,
+				Context:        header.Context,
+			})
+		}
+		select {
+		case <-time.After(f.poll):
+		case <-ctx.Done():
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package collector
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	invalidBlocksDesc = prometheus.NewDesc(
+		"tezos_node_invalid_blocks",
+		"Number of blocks declared invalid by this node.",
+		nil,
+		nil)
+
+	invalidBlockInfoDesc = prometheus.NewDesc(
+		"tezos_node_invalid_block_info",
+		"Hash and level of the latest block declared invalid by this node.",
+		[]string{"block", "level"},
+		nil)
+)
+
+// InvalidBlocksCollector collects invalid blocks stats
+type InvalidBlocksCollector struct {
+	service *tezos.Service
+	timeout time.Duration
+	chainID string
+	info    bool
+}
+
+// NewInvalidBlocksCollector returns a new InvalidBlocksCollector. If info is true then the latest invalid block info metric is reported too.
+func NewInvalidBlocksCollector(service *tezos.Service, timeout time.Duration, chainID string, info bool) *InvalidBlocksCollector {
+	return &InvalidBlocksCollector{
+		service: service,
+		timeout: timeout,
+		chainID: chainID,
+		info:    info,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *InvalidBlocksCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- invalidBlocksDesc
+	ch <- invalidBlockInfoDesc
+}
+
+// Collect implements prometheus.Collector and is called by the Prometheus registry when collecting metrics.
+func (c *InvalidBlocksCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	blocks, err := c.service.GetInvalidBlocks(ctx, c.chainID)
+	var val float64
+	if err != nil {
+		log.WithError(err).Error("error getting invalid blocks")
+		val = 1
+	} else {
+		ch <- prometheus.MustNewConstMetric(invalidBlocksDesc, prometheus.GaugeValue, float64(len(blocks)))
+
+		if c.info && len(blocks) != 0 {
+			latest := blocks[0]
+			for _, b := range blocks[1:] {
+				if b.Level > latest.Level {
+					latest = b
+				}
+			}
+			ch <- prometheus.MustNewConstMetric(invalidBlockInfoDesc, prometheus.GaugeValue, 1, latest.Block, strconv.FormatInt(int64(latest.Level), 10))
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/chains/"+c.chainID+"/invalid_blocks")
+}
@@ -0,0 +1,41 @@
+package collector
+
+import "sync"
+
+// OtherKind is reported instead of operation kinds exceeding the limit
+const OtherKind = "other"
+
+// KindLimiter caps the number of distinct operation kind label values.
+// Kinds seen after the limit is reached are collapsed into OtherKind.
+type KindLimiter struct {
+	max   int
+	mtx   sync.Mutex
+	known map[string]struct{}
+}
+
+// NewKindLimiter returns a new KindLimiter. Zero max disables the limit.
+func NewKindLimiter(max int) *KindLimiter {
+	return &KindLimiter{
+		max:   max,
+		known: make(map[string]struct{}),
+	}
+}
+
+// Kind returns the label value to be used for the operation kind
+func (k *KindLimiter) Kind(kind string) string {
+	if k == nil || k.max == 0 {
+		return kind
+	}
+
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+
+	if _, ok := k.known[kind]; ok {
+		return kind
+	}
+	if len(k.known) >= k.max {
+		return OtherKind
+	}
+	k.known[kind] = struct{}{}
+	return kind
+}
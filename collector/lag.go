@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StreamLagCollector tracks the difference between the processing time of streamed events and the timestamps embedded into them
+type StreamLagCollector struct {
+	lag *prometheus.GaugeVec
+}
+
+// NewStreamLagCollector returns a new StreamLagCollector observing the heads delivered by the block follower.
+func NewStreamLagCollector(follower *BlockFollower) *StreamLagCollector {
+	c := &StreamLagCollector{
+		lag: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "tezos_exporter",
+				Name:      "stream_lag_seconds",
+				Help:      "The difference between the processing time of the last event received from the endpoint and the timestamp embedded into the event.",
+			},
+			[]string{"endpoint"},
+		),
+	}
+
+	endpoint := follower.HeadEndpoint()
+	follower.SubscribeHeads(func(head *tezos.BlockInfo) {
+		c.Observe(endpoint, head.Timestamp)
+	})
+	return c
+}
+
+// Observe records the lag of the event processed now. A nil collector ignores it.
+func (c *StreamLagCollector) Observe(endpoint string, timestamp time.Time) {
+	if c == nil || timestamp.IsZero() {
+		return
+	}
+	c.lag.WithLabelValues(endpoint).Set(time.Since(timestamp).Seconds())
+}
+
+// Describe implements prometheus.Collector
+func (c *StreamLagCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.lag.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *StreamLagCollector) Collect(ch chan<- prometheus.Metric) {
+	c.lag.Collect(ch)
+}
@@ -0,0 +1,62 @@
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// gatherLag returns the reported lags by endpoint
+func gatherLag(t *testing.T, c *StreamLagCollector) map[string]float64 {
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(c))
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	res := make(map[string]float64)
+	for _, f := range families {
+		for _, m := range f.Metric {
+			res[m.Label[0].GetValue()] = m.GetGauge().GetValue()
+		}
+	}
+	return res
+}
+
+func TestStreamLagCollector(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	heads := tezostest.MustFixture(fixtures + "monitor/heads.chunked")
+	heads.Hold = true
+	srv.Handle("/monitor/heads/main", heads)
+	srv.Handle("/chains/main/blocks/*", tezostest.MustFixture(fixtures+"chains/block.json"))
+	srv.Handle("/chains/main/blocks/*/context/constants", tezostest.MustFixture(fixtures+"chains/constants.json"))
+
+	follower := NewBlockFollower(newTestService(t, srv), "main", time.Second, time.Hour, 0, nil, nil)
+	c := NewStreamLagCollector(follower)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	follower.Start(ctx)
+
+	// The head is timestamped 2019-04-10T22:37:08Z
+	headTime := time.Date(2019, 4, 10, 22, 37, 8, 0, time.UTC)
+	require.Eventually(t, func() bool { return testutil.CollectAndCount(c) != 0 }, 5*time.Second, 10*time.Millisecond)
+	lag := gatherLag(t, c)
+	require.Len(t, lag, 1)
+	require.InDelta(t, time.Since(headTime).Seconds(), lag["/monitor/heads/main"], 60)
+
+	// Events without timestamps are ignored
+	c.Observe("/monitor/valid_blocks", time.Time{})
+	c.Observe("/chains/main/mempool/monitor_operations", time.Now().Add(-3*time.Second))
+	lag = gatherLag(t, c)
+	require.Len(t, lag, 2)
+	require.InDelta(t, 3, lag["/chains/main/mempool/monitor_operations"], 1)
+
+	var nilCollector *StreamLagCollector
+	nilCollector.Observe("/monitor/valid_blocks", time.Now())
+}
@@ -0,0 +1,102 @@
+package collector
+
+import (
+	"sync"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Number of recent levels to keep block timestamps for
+const latencyLevels = 8
+
+// EndorsementLatencyCollector measures the delay between a block publication and arrival of endorsements (attestations) for its level to the mempool
+type EndorsementLatencyCollector struct {
+	hist prometheus.Histogram
+
+	mtx        sync.Mutex
+	timestamps map[int]time.Time
+	seen       map[string]int
+}
+
+// NewEndorsementLatencyCollector returns a new EndorsementLatencyCollector fed by the block follower and the mempool collector.
+func NewEndorsementLatencyCollector(follower *BlockFollower, mempool *MempoolOperationsCollector) *EndorsementLatencyCollector {
+	c := &EndorsementLatencyCollector{
+		hist: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "tezos_node",
+			Subsystem: "mempool",
+			Name:      "endorsement_latency_seconds",
+			Help:      "Delay between a block timestamp and arrival of endorsements for its level to the mempool.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+		}),
+		timestamps: make(map[int]time.Time),
+		seen:       make(map[string]int),
+	}
+
+	follower.SubscribeHeads(c.handleHead)
+	mempool.Subscribe(c.handleOperations)
+	return c
+}
+
+func (c *EndorsementLatencyCollector) handleHead(head *tezos.BlockInfo) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.timestamps[head.Level]; !ok {
+		c.timestamps[head.Level] = head.Timestamp
+	}
+
+	for level := range c.timestamps {
+		if level <= head.Level-latencyLevels {
+			delete(c.timestamps, level)
+		}
+	}
+	for hash, level := range c.seen {
+		if level <= head.Level-latencyLevels {
+			delete(c.seen, hash)
+		}
+	}
+}
+
+func (c *EndorsementLatencyCollector) handleOperations(pool string, ops []*tezos.Operation) {
+	now := time.Now()
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for _, op := range ops {
+		for _, elem := range op.Contents {
+			var level int
+			switch el := elem.(type) {
+			case *tezos.EndorsementOperationElem:
+				level = el.Level
+			case *tezos.EndorsementWithSlotOperationElem:
+				level = el.Level
+			default:
+				continue
+			}
+
+			ts, ok := c.timestamps[level]
+			if !ok {
+				continue
+			}
+			// The same operation can be reported by several pools
+			if _, ok := c.seen[op.Hash]; ok && op.Hash != "" {
+				continue
+			}
+			c.seen[op.Hash] = level
+			c.hist.Observe(now.Sub(ts).Seconds())
+		}
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *EndorsementLatencyCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.hist.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *EndorsementLatencyCollector) Collect(ch chan<- prometheus.Metric) {
+	c.hist.Collect(ch)
+}
@@ -0,0 +1,59 @@
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	maintenanceDesc = prometheus.NewDesc(
+		"tezos_exporter_maintenance",
+		"Set to 1 during the planned maintenance window ending at the until label, 0 otherwise. Intended for inhibiting alerts during planned node restarts.",
+		[]string{"until"},
+		nil)
+)
+
+// MaintenanceCollector reports the planned maintenance window set by the operator
+type MaintenanceCollector struct {
+	mtx   sync.Mutex
+	until time.Time
+}
+
+// NewMaintenanceCollector returns a new MaintenanceCollector without a maintenance window.
+func NewMaintenanceCollector() *MaintenanceCollector {
+	return &MaintenanceCollector{}
+}
+
+// Set starts the maintenance window ending at until. A time in the past ends the current window.
+func (c *MaintenanceCollector) Set(until time.Time) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.until = until
+}
+
+// Until returns the end of the current maintenance window or the zero time if there is none
+func (c *MaintenanceCollector) Until() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if !time.Now().Before(c.until) {
+		return time.Time{}
+	}
+	return c.until
+}
+
+// Describe implements prometheus.Collector
+func (c *MaintenanceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- maintenanceDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *MaintenanceCollector) Collect(ch chan<- prometheus.Metric) {
+	until := c.Until()
+	if until.IsZero() {
+		ch <- prometheus.MustNewConstMetric(maintenanceDesc, prometheus.GaugeValue, 0, "")
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(maintenanceDesc, prometheus.GaugeValue, 1, until.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,28 @@
+package collector
+
+import (
+	"runtime"
+)
+
+// MemoryGuard reports whether the process memory footprint exceeds a soft limit.
+// Expensive collectors consult it to skip their work until the pressure is relieved.
+type MemoryGuard struct {
+	limit uint64
+}
+
+// NewMemoryGuard returns a new MemoryGuard. Zero limit disables the guard.
+func NewMemoryGuard(limit uint64) *MemoryGuard {
+	return &MemoryGuard{limit: limit}
+}
+
+// Exceeded returns true if the memory obtained from the OS and not yet released back exceeds the limit
+func (g *MemoryGuard) Exceeded() bool {
+	if g == nil || g.limit == 0 {
+		return false
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	return stats.Sys-stats.HeapReleased > g.limit
+}
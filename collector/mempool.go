@@ -2,50 +2,136 @@ package collector
 
 import (
 	"context"
-	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 )
 
+// OperationsHandler is called for every batch of operations received from the mempool monitor
+type OperationsHandler func(pool string, ops []*tezos.Operation)
+
 // MempoolOperationsCollector collects mempool operations count
 type MempoolOperationsCollector struct {
-	counter        *prometheus.CounterVec
-	rpcTotalHist   prometheus.ObserverVec
-	rpcConnectHist prometheus.Histogram
-	service        *tezos.Service
-	chainID        string
-	interval       time.Duration
+	counter      *prometheus.CounterVec
+	gasLimitHist *prometheus.HistogramVec
+	service      *tezos.Service
+	chainID      string
+	interval     time.Duration
+	explicit     bool
+	kinds        *KindLimiter
+	unknown      *UnknownOperationKindsCollector
+	gate         *StartupGate
+
+	mtx      sync.RWMutex
+	handlers []OperationsHandler
+}
+
+// Subscribe adds a handler to be called for every batch of mempool operations
+func (m *MempoolOperationsCollector) Subscribe(h OperationsHandler) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.handlers = append(m.handlers, h)
+}
+
+func (m *MempoolOperationsCollector) handleOperations(pool string, ops []*tezos.Operation) {
+	for _, op := range ops {
+		for _, elem := range op.Contents {
+			m.unknown.Observe(elem)
+			kind := m.kinds.Kind(elem.OperationElemKind())
+			m.counter.WithLabelValues(pool, op.Protocol, kind).Inc()
+			if g, ok := elem.(tezos.OperationWithGasLimit); ok {
+				m.gasLimitHist.WithLabelValues(pool, kind).Observe(float64(g.OperationGasLimit().Int64()))
+			}
+		}
+	}
+
+	m.mtx.RLock()
+	for _, h := range m.handlers {
+		h(pool, ops)
+	}
+	m.mtx.RUnlock()
 }
 
-func (m *MempoolOperationsCollector) listener(pool string) {
+func (m *MempoolOperationsCollector) listener(ctx context.Context, pool string) {
+	m.gate.Wait()
+
 	ch := make(chan []*tezos.Operation, 100)
 	defer close(ch)
 
 	go func() {
 		for ops := range ch {
+			m.handleOperations(pool, ops)
+		}
+	}()
+
+	for ctx.Err() == nil {
+		err := m.service.MonitorMempool(ctx, m.chainID, &tezos.MempoolFilter{Pools: []string{pool}, Explicit: m.explicit}, ch)
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).WithField("pool", pool).Error("error monitoring mempool operations")
+			select {
+			case <-time.After(m.interval):
+			case <-ctx.Done():
+			}
+		}
+	}
+}
+
+// singleListener requests all pools in one stream and classifies the operations by their errors
+func (m *MempoolOperationsCollector) singleListener(ctx context.Context, pools []string) {
+	m.gate.Wait()
+
+	// Validated operations are reported under the configured name
+	labels := make(map[string]string, len(pools))
+	for _, p := range pools {
+		if p == tezos.PoolApplied {
+			labels[tezos.PoolValidated] = p
+		} else {
+			labels[p] = p
+		}
+	}
+
+	ch := make(chan []*tezos.OperationWithError, 100)
+	defer close(ch)
+
+	go func() {
+		for ops := range ch {
+			byPool := make(map[string][]*tezos.Operation)
 			for _, op := range ops {
-				for _, elem := range op.Contents {
-					m.counter.WithLabelValues(pool, op.Protocol, elem.OperationElemKind()).Inc()
+				pool := tezos.MempoolPool(op.Error)
+				if l, ok := labels[pool]; ok {
+					pool = l
 				}
+				byPool[pool] = append(byPool[pool], &op.Operation)
+			}
+			for pool, ops := range byPool {
+				m.handleOperations(pool, ops)
 			}
 		}
 	}()
 
-	for {
-		err := m.service.MonitorMempoolOperations(context.Background(), m.chainID, pool, ch)
-		if err != nil {
-			log.WithError(err).WithField("pool", pool).Error("error monitoring mempool operations")
-			<-time.After(m.interval)
+	filter := &tezos.MempoolFilter{Pools: pools, Explicit: m.explicit}
+	for ctx.Err() == nil {
+		err := m.service.MonitorMempoolWithErrors(ctx, m.chainID, filter, ch)
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).WithField("pools", filter.Query()).Error("error monitoring mempool operations")
+			select {
+			case <-time.After(m.interval):
+			case <-ctx.Done():
+			}
 		}
 	}
 }
 
 // NewMempoolOperationsCollectorCollector returns new mempool collector for given pools like "applied", "refused" etc.
-func NewMempoolOperationsCollectorCollector(service *tezos.Service, chainID string, pools []string, interval time.Duration) *MempoolOperationsCollector {
+// Operation kind label values are passed through kinds. Operations of unknown kinds are reported to unknown if not nil.
+// With explicit set every pool is requested using the query form of newer nodes, see tezos.MempoolFilter.
+// Monitoring starts once the gate opens and stops when the context is done.
+// With single set all pools are requested in one stream and the operations are classified by their errors.
+func NewMempoolOperationsCollectorCollector(ctx context.Context, service *tezos.Service, chainID string, pools []string, interval time.Duration, explicit, single bool, kinds *KindLimiter, unknown *UnknownOperationKindsCollector, gate *StartupGate) *MempoolOperationsCollector {
 	c := &MempoolOperationsCollector{
 		counter: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
@@ -56,50 +142,33 @@ func NewMempoolOperationsCollectorCollector(service *tezos.Service, chainID stri
 			},
 			[]string{"pool", "proto", "kind"},
 		),
-		rpcTotalHist: prometheus.NewHistogramVec(
+		gasLimitHist: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
-				Namespace: "tezos_rpc",
-				Subsystem: "mempool",
-				Name:      "monitor_connection_total_duration_seconds",
-				Help:      "The total life time of the mempool monitor RPC connection.",
-				Buckets:   prometheus.ExponentialBuckets(0.25, 2, 12),
-			},
-			[]string{},
-		),
-		rpcConnectHist: prometheus.NewHistogram(
-			prometheus.HistogramOpts{
-				Namespace: "tezos_rpc",
+				Namespace: "tezos_node",
 				Subsystem: "mempool",
-				Name:      "monitor_connection_connect_duration_seconds",
-				Help:      "Mempool monitor (re)connection duration (time until HTTP header arrives).",
-				Buckets:   prometheus.ExponentialBuckets(0.25, 2, 12),
+				Name:      "operation_gas_limit",
+				Help:      "Gas limit of the mempool manager operations.",
+				Buckets:   prometheus.ExponentialBuckets(100, 2, 15),
 			},
+			[]string{"pool", "kind"},
 		),
+		service:  service,
 		chainID:  chainID,
 		interval: interval,
+		explicit: explicit,
+		kinds:    kinds,
+		unknown:  unknown,
+		gate:     gate,
 	}
 
-	it := promhttp.InstrumentTrace{
-		GotConn: func(t float64) {
-			c.rpcConnectHist.Observe(t)
-		},
-	}
-
-	client := *service.Client
-	if client.Transport == nil {
-		client.Transport = http.DefaultTransport
-	}
-
-	client.Transport = promhttp.InstrumentRoundTripperDuration(c.rpcTotalHist, client.Transport)
-	client.Transport = promhttp.InstrumentRoundTripperTrace(&it, client.Transport)
-
-	srv := *service
-	srv.Client = &client
-	c.service = &srv
-
-	for _, p := range pools {
-		log.WithField("pool", p).Info("starting mempool monitor")
-		go c.listener(p)
+	if single {
+		log.WithField("pools", strings.Join(pools, ",")).Info("starting mempool monitor")
+		go c.singleListener(ctx, pools)
+	} else {
+		for _, p := range pools {
+			log.WithField("pool", p).Info("starting mempool monitor")
+			go c.listener(ctx, p)
+		}
 	}
 
 	return c
@@ -108,13 +177,11 @@ func NewMempoolOperationsCollectorCollector(service *tezos.Service, chainID stri
 // Describe implements prometheus.Collector
 func (m *MempoolOperationsCollector) Describe(ch chan<- *prometheus.Desc) {
 	m.counter.Describe(ch)
-	m.rpcTotalHist.Describe(ch)
-	m.rpcConnectHist.Describe(ch)
+	m.gasLimitHist.Describe(ch)
 }
 
 // Collect implements prometheus.Collector
 func (m *MempoolOperationsCollector) Collect(ch chan<- prometheus.Metric) {
 	m.counter.Collect(ch)
-	m.rpcTotalHist.Collect(ch)
-	m.rpcConnectHist.Collect(ch)
+	m.gasLimitHist.Collect(ch)
 }
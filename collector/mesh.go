@@ -0,0 +1,95 @@
+package collector
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	meshConnectedDesc = prometheus.NewDesc(
+		"tezos_node_mesh_connected",
+		"Set to 1 if the node is connected to the mesh node, 0 otherwise. Not reported while the mesh node identity can't be fetched.",
+		[]string{"peer_node"},
+		nil)
+)
+
+// MeshCollector checks the node's connections against the identities of the other nodes of a private mesh.
+// Exporters of all mesh nodes together produce the connectivity matrix. The identities are requested until fetched once.
+type MeshCollector struct {
+	service *tezos.Service
+	timeout time.Duration
+	mesh    map[string]*tezos.Service
+
+	mtx sync.Mutex
+	ids map[string]string
+}
+
+// NewMeshCollector returns a new MeshCollector. mesh maps the names reported in the peer_node label to the RPC services of the other nodes.
+func NewMeshCollector(service *tezos.Service, timeout time.Duration, mesh map[string]*tezos.Service) *MeshCollector {
+	return &MeshCollector{
+		service: service,
+		timeout: timeout,
+		mesh:    mesh,
+		ids:     make(map[string]string, len(mesh)),
+	}
+}
+
+// identity returns the peer ID of the mesh node fetching it once
+func (c *MeshCollector) identity(ctx context.Context, name string, service *tezos.Service) (string, error) {
+	c.mtx.Lock()
+	id, ok := c.ids[name]
+	c.mtx.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	id, err := service.GetNetworkSelf(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.mtx.Lock()
+	c.ids[name] = id
+	c.mtx.Unlock()
+	return id, nil
+}
+
+// Describe implements prometheus.Collector
+func (c *MeshCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- meshConnectedDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *MeshCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	conns, err := c.service.GetNetworkConnections(ctx)
+	if err != nil {
+		// Reported as failed by NetworkCollector
+		log.WithError(err).Error("error getting connections")
+		return
+	}
+	connected := make(map[string]struct{}, len(conns))
+	for _, conn := range conns {
+		connected[conn.PeerID] = struct{}{}
+	}
+
+	for name, service := range c.mesh {
+		id, err := c.identity(ctx, name, service)
+		if err != nil {
+			log.WithError(err).WithField("peer_node", name).Error("error getting mesh node identity")
+			continue
+		}
+		var val float64
+		if _, ok := connected[id]; ok {
+			val = 1
+		}
+		ch <- prometheus.MustNewConstMetric(meshConnectedDesc, prometheus.GaugeValue, val, name)
+	}
+}
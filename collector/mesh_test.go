@@ -0,0 +1,50 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeshCollector(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+	srv.Handle("/network/connections", tezostest.MustFixture(fixtures+"network/connections.json"))
+
+	peer := tezostest.NewServer()
+	defer peer.Close()
+	peer.Handle("/network/self", &tezostest.Response{Body: []byte(`"idt5qvkLiJ15rb6yJU1bjpGmdyYnPJ"`)})
+
+	// The identity isn't known until the node responds
+	other := tezostest.NewServer()
+	defer other.Close()
+	other.Handle("/network/self",
+		&tezostest.Response{Status: 500, ContentType: "text/plain", Body: []byte("Internal Server Error")},
+		&tezostest.Response{Body: []byte(`"idrnHcGMrFxiYsmxf5Cqd6NhUTUU8X"`)},
+	)
+
+	c := NewMeshCollector(newTestService(t, srv), time.Second, map[string]*tezos.Service{
+		"peer":  newTestService(t, peer),
+		"other": newTestService(t, other),
+	})
+
+	requireMetrics(t, c, `
+# HELP tezos_node_mesh_connected Set to 1 if the node is connected to the mesh node, 0 otherwise. Not reported while the mesh node identity can't be fetched.
+# TYPE tezos_node_mesh_connected gauge
+tezos_node_mesh_connected{peer_node="peer"} 1
+`)
+	requireMetrics(t, c, `
+# HELP tezos_node_mesh_connected Set to 1 if the node is connected to the mesh node, 0 otherwise. Not reported while the mesh node identity can't be fetched.
+# TYPE tezos_node_mesh_connected gauge
+tezos_node_mesh_connected{peer_node="other"} 0
+tezos_node_mesh_connected{peer_node="peer"} 1
+`)
+
+	// The identities are fetched once and the connections on every scrape
+	require.Equal(t, 1, peer.Requests("/network/self"))
+	require.Equal(t, 2, other.Requests("/network/self"))
+	require.Equal(t, 2, srv.Requests("/network/connections"))
+}
@@ -0,0 +1,55 @@
+package collector
+
+import (
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	metadataDowngradesDesc = prometheus.NewDesc(
+		"tezos_exporter_block_metadata_downgrades_total",
+		"The total number of head blocks fetched without metadata after the node timed out or refused to return too large metadata.",
+		nil,
+		nil)
+)
+
+// BlockMetadataCollector reports whether the metadata dependent metrics reflect the head block
+type BlockMetadataCollector struct {
+	follower  *BlockFollower
+	available prometheus.Gauge
+}
+
+// NewBlockMetadataCollector returns a new BlockMetadataCollector.
+func NewBlockMetadataCollector(follower *BlockFollower) *BlockMetadataCollector {
+	c := &BlockMetadataCollector{
+		follower: follower,
+		available: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tezos_node",
+			Name:      "head_block_metadata_available",
+			Help:      "Whether the metadata of the head block was received. Balance updates, rewards, deposits, cycle and protocol metrics aren't updated for blocks without it.",
+		}),
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+func (c *BlockMetadataCollector) handleBlock(block *tezos.Block) {
+	if block.HasMetadata() {
+		c.available.Set(1)
+	} else {
+		c.available.Set(0)
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *BlockMetadataCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.available.Describe(ch)
+	ch <- metadataDowngradesDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *BlockMetadataCollector) Collect(ch chan<- prometheus.Metric) {
+	c.available.Collect(ch)
+	ch <- prometheus.MustNewConstMetric(metadataDowngradesDesc, prometheus.CounterValue, float64(c.follower.MetadataDowngrades()))
+}
@@ -2,13 +2,13 @@ package collector
 
 import (
 	"context"
+	"fmt"
 	"net"
-	"net/http"
+	"sort"
 	"time"
 
 	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -30,8 +30,14 @@ var (
 
 	connsDesc = prometheus.NewDesc(
 		"tezos_node_connections",
-		"Current number of connections to/from this node.",
-		[]string{"direction", "private"},
+		"Current number of connections to/from this node by the address family of the remote point.",
+		[]string{"direction", "private", "family"},
+		nil)
+
+	connsByVersionDesc = prometheus.NewDesc(
+		"tezos_node_connections_by_version",
+		"Current number of connections grouped by the announced network version.",
+		[]string{"version"},
 		nil)
 
 	peersDesc = prometheus.NewDesc(
@@ -42,10 +48,60 @@ var (
 
 	pointsDesc = prometheus.NewDesc(
 		"tezos_node_points",
-		"Stats about known network points.",
-		[]string{"trusted", "event_kind"},
+		"Stats about known network points. The family is \"hostname\" for points known by unresolved host names.",
+		[]string{"trusted", "event_kind", "family"},
+		nil)
+
+	peerSentBytesDesc = prometheus.NewDesc(
+		"tezos_node_peer_sent_bytes_total",
+		"Total number of bytes sent to the peer.",
+		[]string{"peer_id"},
+		nil)
+
+	peerRecvBytesDesc = prometheus.NewDesc(
+		"tezos_node_peer_recv_bytes_total",
+		"Total number of bytes received from the peer.",
+		[]string{"peer_id"},
+		nil)
+
+	peerInflowDesc = prometheus.NewDesc(
+		"tezos_node_peer_current_inflow_bytes_per_second",
+		"Current inflow from the peer.",
+		[]string{"peer_id"},
+		nil)
+
+	peerOutflowDesc = prometheus.NewDesc(
+		"tezos_node_peer_current_outflow_bytes_per_second",
+		"Current outflow to the peer.",
+		[]string{"peer_id"},
+		nil)
+
+	peerStateDesc = prometheus.NewDesc(
+		"tezos_node_peer_state",
+		"State of the peer.",
+		[]string{"peer_id", "state"},
 		nil)
 
+	trustedPointStateDesc = prometheus.NewDesc(
+		"tezos_node_trusted_point_state",
+		"State of the trusted point.",
+		[]string{"address", "state"},
+		nil)
+
+	trustedPointEstablishedDesc = prometheus.NewDesc(
+		"tezos_node_trusted_point_last_established_connection_timestamp_seconds",
+		"The time of the last connection established with the trusted point.",
+		[]string{"address"},
+		nil)
+
+	trustedPointFailedDesc = prometheus.NewDesc(
+		"tezos_node_trusted_point_last_failed_connection_timestamp_seconds",
+		"The time of the last failed connection attempt to the trusted point.",
+		[]string{"address"},
+		nil)
+
+	// rpcFailedDesc is shared by all scrape time collectors but described by NetworkCollector only
+	// as the registry doesn't allow the same descriptor to be registered twice
 	rpcFailedDesc = prometheus.NewDesc(
 		"tezos_rpc_failed",
 		"A gauge that is set to 1 when a metrics collection RPC failed during the current scrape, 0 otherwise.",
@@ -58,15 +114,37 @@ type NetworkCollector struct {
 	service      *tezos.Service
 	timeout      time.Duration
 	chainID      string
+	guard        *MemoryGuard
+	perPeerLimit int
+	cache        *TTLCache
+	peerStates   []string
+	pointStates  []string
+	light        bool
+	restarts     *RestartDetector
+	trusted      bool
+	families     *hostFamilies
 	bootstrapped prometheus.Gauge
 }
 
-// NewNetworkCollector returns a new NetworkCollector.
-func NewNetworkCollector(service *tezos.Service, timeout time.Duration, chainID string) *NetworkCollector {
+// NewNetworkCollector returns a new NetworkCollector. Peers and points stats are skipped while the guard limit is exceeded.
+// Per peer metrics are reported for at most perPeerLimit peers, zero disables them. Peers and points lists are kept in the cache under "peers" and "points" keys.
+// peerStates and pointStates restrict enumeration to peers and points in given states using the RPC filter, empty state matches all.
+// In the light mode peers and points are not enumerated at all and the running peers count is derived from the connections list.
+// Traffic totals are re-based by restarts if not nil. With trusted set per point metrics are reported for the enumerated trusted points.
+// Points known by host names are resolved to get their address family if resolve is set, each name is looked up once.
+func NewNetworkCollector(service *tezos.Service, timeout time.Duration, chainID string, guard *MemoryGuard, perPeerLimit int, cache *TTLCache, peerStates, pointStates []string, light bool, restarts *RestartDetector, trusted, resolve bool) *NetworkCollector {
 	c := &NetworkCollector{
-		service: service,
-		timeout: timeout,
-		chainID: chainID,
+		service:      service,
+		timeout:      timeout,
+		chainID:      chainID,
+		guard:        guard,
+		perPeerLimit: perPeerLimit,
+		cache:        cache,
+		peerStates:   peerStates,
+		pointStates:  pointStates,
+		light:        light,
+		restarts:     restarts,
+		trusted:      trusted,
 		bootstrapped: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: "tezos_node",
 			Name:      "bootstrapped",
@@ -74,6 +152,10 @@ func NewNetworkCollector(service *tezos.Service, timeout time.Duration, chainID
 		}),
 	}
 
+	if resolve {
+		c.families = newHostFamilies()
+	}
+
 	go c.bootstrappedPollLoop()
 	return c
 }
@@ -126,26 +208,43 @@ func (c *NetworkCollector) bootstrappedPollLoop() {
 	}
 }
 
-// Describe implements prometheus.Collector.
+// Describe implements prometheus.Collector. The descriptors are listed explicitly to avoid calling RPCs at registration.
 func (c *NetworkCollector) Describe(ch chan<- *prometheus.Desc) {
-	prometheus.DescribeByCollect(c, ch)
+	ch <- sentBytesDesc
+	ch <- recvBytesDesc
+	ch <- connsDesc
+	ch <- connsByVersionDesc
+	ch <- peersDesc
+	ch <- pointsDesc
+	ch <- peerSentBytesDesc
+	ch <- peerRecvBytesDesc
+	ch <- peerInflowDesc
+	ch <- peerOutflowDesc
+	ch <- peerStateDesc
+	ch <- trustedPointStateDesc
+	ch <- trustedPointEstablishedDesc
+	ch <- trustedPointFailedDesc
+	ch <- rpcFailedDesc
+	c.bootstrapped.Describe(ch)
 }
 
-func getConnStats(ctx context.Context, service *tezos.Service) (map[string]map[string]int, error) {
+type connKey struct {
+	direction, private, family string
+}
+
+func getConnStats(ctx context.Context, service *tezos.Service) (map[connKey]int, []*tezos.NetworkConnection, error) {
 	conns, err := service.GetNetworkConnections(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	connStats := map[string]map[string]int{
-		"incoming": {
-			"false": 0,
-			"true":  0,
-		},
-		"outgoing": {
-			"false": 0,
-			"true":  0,
-		},
+	connStats := make(map[connKey]int)
+	for _, direction := range []string{"incoming", "outgoing"} {
+		for _, private := range []string{"false", "true"} {
+			for _, family := range []string{"ipv4", "ipv6"} {
+				connStats[connKey{direction, private, family}] = 0
+			}
+		}
 	}
 
 	for _, conn := range conns {
@@ -158,39 +257,104 @@ func getConnStats(ctx context.Context, service *tezos.Service) (map[string]map[s
 			private = "true"
 		}
 
-		connStats[direction][private]++
+		connStats[connKey{direction, private, addressFamily(conn.IDPoint.Addr)}]++
 	}
 
-	return connStats, nil
+	return connStats, conns, nil
 }
 
-func getPointStats(ctx context.Context, service *tezos.Service) (map[string]map[string]int, error) {
-	points, err := service.GetNetworkPoints(ctx, "")
-	if err != nil {
-		return nil, err
+// connVersionStats returns connections count by the announced version formatted as name/major.minor
+func connVersionStats(conns []*tezos.NetworkConnection) map[string]int {
+	stats := make(map[string]int)
+	for _, conn := range conns {
+		version := "unknown"
+		if v := conn.Version(); v != nil {
+			version = fmt.Sprintf("%s/%d.%d", v.Name, v.Major, v.Minor)
+		}
+		stats[version]++
 	}
+	return stats
+}
 
-	pointStats := map[string]map[string]int{
-		"false": {},
-		"true":  {},
+// connectedPeers returns the number of distinct connected peers
+func connectedPeers(conns []*tezos.NetworkConnection) int {
+	peers := make(map[string]struct{}, len(conns))
+	for _, conn := range conns {
+		peers[conn.PeerID] = struct{}{}
 	}
+	return len(peers)
+}
 
+type pointKey struct {
+	trusted, eventKind, family string
+}
+
+// getPointStats returns points stats by the trust flag, state and address family. Point addresses are normalized and the points
+// listed more than once under different notations of the same address are counted once. Host names are resolved if families is not nil.
+func getPointStats(ctx context.Context, service *tezos.Service, cache *TTLCache, states []string, families *hostFamilies) (map[pointKey]int, []*tezos.NetworkPoint, error) {
+	v, err := cache.Get("points", func() (interface{}, error) {
+		var points []*tezos.NetworkPoint
+		seen := make(map[string]struct{})
+		for _, state := range states {
+			p, err := service.GetNetworkPoints(ctx, state)
+			if err != nil {
+				return nil, err
+			}
+			for _, point := range p {
+				point.Address = normalizePointAddress(point.Address)
+				if _, ok := seen[point.Address]; ok {
+					continue
+				}
+				seen[point.Address] = struct{}{}
+				points = append(points, point)
+			}
+		}
+		return points, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	points := v.([]*tezos.NetworkPoint)
+
+	pointStats := make(map[pointKey]int)
+	hosts := make(map[string]struct{})
 	for _, point := range points {
 		trusted := "false"
 		if point.Trusted {
 			trusted = "true"
 		}
+		host, _ := splitPointAddress(point.Address)
+		family := addressFamily(host)
+		if families != nil {
+			family = families.family(ctx, host)
+			hosts[host] = struct{}{}
+		}
 
-		pointStats[trusted][point.State.EventKind]++
+		pointStats[pointKey{trusted, point.State.EventKind, family}]++
+	}
+	if families != nil {
+		families.retain(hosts)
 	}
 
-	return pointStats, nil
+	return pointStats, points, nil
 }
-func getPeerStats(ctx context.Context, service *tezos.Service) (map[string]map[string]int, error) {
-	peers, err := service.GetNetworkPeers(ctx, "")
+
+func getPeerStats(ctx context.Context, service *tezos.Service, cache *TTLCache, states []string) (map[string]map[string]int, []*tezos.NetworkPeer, error) {
+	v, err := cache.Get("peers", func() (interface{}, error) {
+		var peers []*tezos.NetworkPeer
+		for _, state := range states {
+			p, err := service.GetNetworkPeers(ctx, state)
+			if err != nil {
+				return nil, err
+			}
+			peers = append(peers, p...)
+		}
+		return peers, nil
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	peers := v.([]*tezos.NetworkPeer)
 
 	peerStats := map[string]map[string]int{
 		"false": {},
@@ -206,47 +370,80 @@ func getPeerStats(ctx context.Context, service *tezos.Service) (map[string]map[s
 		peerStats[trusted][peer.State]++
 	}
 
-	return peerStats, nil
+	return peerStats, peers, nil
 }
 
-// Collect implements prometheus.Collector and is called by the Prometheus registry when collecting metrics.
-func (c *NetworkCollector) Collect(ch chan<- prometheus.Metric) {
-	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
-	defer cancel()
+// collectPeers reports per peer metrics for running peers first and then for the rest up to the limit
+func (c *NetworkCollector) collectPeers(ch chan<- prometheus.Metric, peers []*tezos.NetworkPeer) {
+	sorted := make([]*tezos.NetworkPeer, len(peers))
+	copy(sorted, peers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, rj := sorted[i].State == "running", sorted[j].State == "running"
+		if ri != rj {
+			return ri
+		}
+		return sorted[i].PeerID < sorted[j].PeerID
+	})
 
-	client := *c.service.Client
-	transport := client.Transport
-	if transport == nil {
-		transport = http.DefaultTransport
+	if len(sorted) > c.perPeerLimit {
+		sorted = sorted[:c.perPeerLimit]
 	}
 
-	var path string
-	client.Transport = promhttp.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
-		path = r.URL.Path
-		return transport.RoundTrip(r)
-	})
+	for _, peer := range sorted {
+		ch <- prometheus.MustNewConstMetric(peerSentBytesDesc, prometheus.CounterValue, float64(peer.Stat.TotalBytesSent), peer.PeerID)
+		ch <- prometheus.MustNewConstMetric(peerRecvBytesDesc, prometheus.CounterValue, float64(peer.Stat.TotalBytesRecv), peer.PeerID)
+		ch <- prometheus.MustNewConstMetric(peerInflowDesc, prometheus.GaugeValue, float64(peer.Stat.CurrentInflow), peer.PeerID)
+		ch <- prometheus.MustNewConstMetric(peerOutflowDesc, prometheus.GaugeValue, float64(peer.Stat.CurrentOutflow), peer.PeerID)
+		ch <- prometheus.MustNewConstMetric(peerStateDesc, prometheus.GaugeValue, 1, peer.PeerID, peer.State)
+	}
+}
 
-	srv := *c.service
-	srv.Client = &client
+// collectTrustedPoints reports per point metrics for the trusted points. Their number is bounded by the node configuration.
+func collectTrustedPoints(ch chan<- prometheus.Metric, points []*tezos.NetworkPoint) {
+	for _, point := range points {
+		if !point.Trusted {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(trustedPointStateDesc, prometheus.GaugeValue, 1, point.Address, point.State.EventKind)
+		if point.LastEstablishedConnection != nil {
+			ch <- prometheus.MustNewConstMetric(trustedPointEstablishedDesc, prometheus.GaugeValue, float64(point.LastEstablishedConnection.Timestamp.Unix()), point.Address)
+		}
+		if !point.LastFailedConnection.IsZero() {
+			ch <- prometheus.MustNewConstMetric(trustedPointFailedDesc, prometheus.GaugeValue, float64(point.LastFailedConnection.Unix()), point.Address)
+		}
+	}
+}
 
-	stats, err := srv.GetNetworkStats(ctx)
+// Collect implements prometheus.Collector and is called by the Prometheus registry when collecting metrics.
+func (c *NetworkCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	stats, err := c.service.GetNetworkStats(ctx)
 	if err == nil {
-		ch <- prometheus.MustNewConstMetric(sentBytesDesc, prometheus.CounterValue, float64(stats.TotalBytesSent))
-		ch <- prometheus.MustNewConstMetric(recvBytesDesc, prometheus.CounterValue, float64(stats.TotalBytesRecv))
+		sent := c.restarts.Observe("tezos_node_sent_bytes_total", float64(stats.TotalBytesSent))
+		recv := c.restarts.Observe("tezos_node_recv_bytes_total", float64(stats.TotalBytesRecv))
+		ch <- prometheus.MustNewConstMetric(sentBytesDesc, prometheus.CounterValue, sent)
+		ch <- prometheus.MustNewConstMetric(recvBytesDesc, prometheus.CounterValue, recv)
 	}
 	var val float64
 	if err != nil {
 		log.WithError(err).Error("error getting network stats")
 		val = 1
 	}
-	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, path)
+	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/network/stat")
 
-	connStats, err := getConnStats(ctx, &srv)
+	connStats, conns, err := getConnStats(ctx, c.service)
 	if err == nil {
-		for direction, stats := range connStats {
-			for private, count := range stats {
-				ch <- prometheus.MustNewConstMetric(connsDesc, prometheus.GaugeValue, float64(count), direction, private)
-			}
+		for k, count := range connStats {
+			ch <- prometheus.MustNewConstMetric(connsDesc, prometheus.GaugeValue, float64(count), k.direction, k.private, k.family)
+		}
+		for version, count := range connVersionStats(conns) {
+			ch <- prometheus.MustNewConstMetric(connsByVersionDesc, prometheus.GaugeValue, float64(count), version)
+		}
+		if c.light {
+			// The trust flag is not known without the peers list
+			ch <- prometheus.MustNewConstMetric(peersDesc, prometheus.GaugeValue, float64(connectedPeers(conns)), "unknown", "running")
 		}
 	}
 	if err != nil {
@@ -255,39 +452,51 @@ func (c *NetworkCollector) Collect(ch chan<- prometheus.Metric) {
 	} else {
 		val = 0
 	}
-	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, path)
-
-	peerStats, err := getPeerStats(ctx, &srv)
-	if err == nil {
-		for trusted, stats := range peerStats {
-			for state, count := range stats {
-				ch <- prometheus.MustNewConstMetric(peersDesc, prometheus.GaugeValue, float64(count), trusted, state)
+	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/network/connections")
+
+	switch {
+	case c.light:
+		// Peers and points are not enumerated in the light mode
+	case c.guard.Exceeded():
+		log.Warn("soft memory limit exceeded, skipping peer and point stats")
+	default:
+		peerStats, peers, err := getPeerStats(ctx, c.service, c.cache, c.peerStates)
+		if err == nil {
+			for trusted, stats := range peerStats {
+				for state, count := range stats {
+					ch <- prometheus.MustNewConstMetric(peersDesc, prometheus.GaugeValue, float64(count), trusted, state)
+				}
+			}
+			if c.perPeerLimit > 0 {
+				c.collectPeers(ch, peers)
 			}
 		}
-	}
-	if err != nil {
-		log.WithError(err).Error("error getting peer stats")
-		val = 1
-	} else {
-		val = 0
-	}
-	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, path)
+		if err != nil {
+			log.WithError(err).Error("error getting peer stats")
+			val = 1
+		} else {
+			val = 0
+		}
+		// The list may come from the cache
+		ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/network/peers")
 
-	pointStats, err := getPointStats(ctx, &srv)
-	if err == nil {
-		for trusted, stats := range pointStats {
-			for eventKind, count := range stats {
-				ch <- prometheus.MustNewConstMetric(pointsDesc, prometheus.GaugeValue, float64(count), trusted, eventKind)
+		pointStats, points, err := getPointStats(ctx, c.service, c.cache, c.pointStates, c.families)
+		if err == nil {
+			for k, count := range pointStats {
+				ch <- prometheus.MustNewConstMetric(pointsDesc, prometheus.GaugeValue, float64(count), k.trusted, k.eventKind, k.family)
+			}
+			if c.trusted {
+				collectTrustedPoints(ch, points)
 			}
 		}
+		if err != nil {
+			log.WithError(err).Error("error getting point stats")
+			val = 1
+		} else {
+			val = 0
+		}
+		ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/network/points")
 	}
-	if err != nil {
-		log.WithError(err).Error("error getting point stats")
-		val = 1
-	} else {
-		val = 0
-	}
-	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, path)
 
 	c.bootstrapped.Collect(ch)
 }
@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkCollectorTrustedPoints(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/network/stat", tezostest.MustFixture(fixtures+"network/stat.json"))
+	srv.Handle("/network/connections", tezostest.MustFixture(fixtures+"network/connections.json"))
+	srv.Handle("/network/peers", &tezostest.Response{Body: []byte(`[]`)})
+	srv.Handle("/network/points", &tezostest.Response{Body: []byte(`[
+		["[::ffff:45.79.146.133]:9732", {"trusted": true, "state": {"event_kind": "running", "p2p_peer_id": "idt5qvkLiJ15rb6yJU1bjpGmdyYnPJ"},
+			"last_established_connection": ["idt5qvkLiJ15rb6yJU1bjpGmdyYnPJ", "2024-07-22T09:00:00Z"]}],
+		["boot.tzinit.org:9732", {"trusted": true, "state": {"event_kind": "disconnected"}, "last_failed_connection": "2024-07-22T08:00:00Z"}],
+		["73.247.92.150:9732", {"trusted": false, "state": {"event_kind": "disconnected"}, "last_failed_connection": "2024-07-22T08:30:00Z"}]
+	]`)})
+
+	c := NewNetworkCollector(newTestService(t, srv), time.Second, "main", nil, 0, nil, []string{""}, []string{""}, false, nil, true, false)
+
+	// Untrusted points aren't reported
+	requireMetrics(t, c, `
+# HELP tezos_node_trusted_point_last_established_connection_timestamp_seconds The time of the last connection established with the trusted point.
+# TYPE tezos_node_trusted_point_last_established_connection_timestamp_seconds gauge
+tezos_node_trusted_point_last_established_connection_timestamp_seconds{address="45.79.146.133:9732"} 1.7216388e+09
+# HELP tezos_node_trusted_point_last_failed_connection_timestamp_seconds The time of the last failed connection attempt to the trusted point.
+# TYPE tezos_node_trusted_point_last_failed_connection_timestamp_seconds gauge
+tezos_node_trusted_point_last_failed_connection_timestamp_seconds{address="boot.tzinit.org:9732"} 1.7216352e+09
+# HELP tezos_node_trusted_point_state State of the trusted point.
+# TYPE tezos_node_trusted_point_state gauge
+tezos_node_trusted_point_state{address="45.79.146.133:9732",state="running"} 1
+tezos_node_trusted_point_state{address="boot.tzinit.org:9732",state="disconnected"} 1
+`,
+		"tezos_node_trusted_point_state",
+		"tezos_node_trusted_point_last_established_connection_timestamp_seconds",
+		"tezos_node_trusted_point_last_failed_connection_timestamp_seconds")
+}
+
+func TestNetworkCollectorGatedRegistration(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+	srv.Handle("/network/*", &tezostest.Response{Body: []byte(`[]`)})
+
+	c := NewNetworkCollector(newTestService(t, srv), time.Second, "main", nil, 0, nil, []string{""}, []string{""}, false, nil, true, false)
+	gate := &StartupGate{ready: make(chan struct{})}
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(Gated(gate, c))
+
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+	require.Empty(t, mfs)
+	require.Zero(t, srv.Requests("/network/*"))
+}
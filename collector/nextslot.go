@@ -0,0 +1,233 @@
+package collector
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	nextBakingSlotLevelDesc = prometheus.NewDesc(
+		"tezos_baker_next_baking_slot_level",
+		"The level of the next round 0 baking slot of the delegate within the current and the next cycle.",
+		[]string{"delegate"},
+		nil)
+
+	nextBakingSlotETADesc = prometheus.NewDesc(
+		"tezos_baker_next_baking_slot_eta_seconds",
+		"The estimated time left until the next round 0 baking slot of the delegate assuming no missed blocks.",
+		[]string{"delegate"},
+		nil)
+)
+
+// NextSlotCollector exposes the next baking slot of each of the watched bakers. The rights are requested once per cycle
+// for the current and the next one so the slot is known across the cycle boundary. Bakers without slots in the lookahead window
+// aren't reported.
+type NextSlotCollector struct {
+	service  *tezos.Service
+	timeout  time.Duration
+	chainID  string
+	follower *BlockFollower
+	bakers   []string
+
+	mtx       sync.Mutex
+	cycle     int // the last cycle rights are requested at
+	slots     map[string][]int
+	head      int
+	timestamp time.Time
+	start     int // the first level of the current cycle
+}
+
+// SlotGap is a time span without baking slots of any of the watched bakers
+type SlotGap struct {
+	FromLevel int       `json:"from_level"`
+	ToLevel   int       `json:"to_level"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Seconds   float64   `json:"duration_seconds"`
+}
+
+// NewNextSlotCollector returns a new NextSlotCollector fed by the block follower. Repeated bakers are watched once.
+func NewNextSlotCollector(service *tezos.Service, timeout time.Duration, chainID string, follower *BlockFollower, bakers []string) *NextSlotCollector {
+	seen := make(map[string]struct{}, len(bakers))
+	unique := make([]string, 0, len(bakers))
+	for _, baker := range bakers {
+		if _, ok := seen[baker]; !ok {
+			seen[baker] = struct{}{}
+			unique = append(unique, baker)
+		}
+	}
+	bakers = unique
+
+	c := &NextSlotCollector{
+		service:  service,
+		timeout:  timeout,
+		chainID:  chainID,
+		follower: follower,
+		bakers:   bakers,
+		cycle:    -1,
+		slots:    make(map[string][]int, len(bakers)),
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+func (c *NextSlotCollector) handleBlock(block *tezos.Block) {
+	if !block.HasMetadata() {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.head = block.Header.Level
+	c.timestamp = block.Header.Timestamp
+	level := block.Metadata.CurrentLevel()
+	c.start = level.Level - level.CyclePosition
+	if cycle := level.Cycle; cycle != c.cycle {
+		c.cycle = cycle
+		go c.update(cycle, block.Hash)
+	}
+}
+
+func (c *NextSlotCollector) update(cycle int, blockID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	slots, err := c.getSlots(ctx, cycle, blockID)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if err != nil {
+		log.WithError(err).WithField("cycle", cycle).Error("error getting baking rights")
+		// Retry with the next block
+		if c.cycle == cycle {
+			c.cycle = -1
+		}
+		return
+	}
+	// The cycle has changed while the rights were requested
+	if c.cycle != cycle {
+		return
+	}
+	c.slots = slots
+}
+
+func (c *NextSlotCollector) getSlots(ctx context.Context, cycle int, blockID string) (map[string][]int, error) {
+	slots := make(map[string][]int, len(c.bakers))
+	maxRound := 0
+	for _, baker := range c.bakers {
+		var levels []int
+		for _, cy := range []int{cycle, cycle + 1} {
+			cy := cy
+			rights, err := c.service.GetBakingRights(ctx, c.chainID, blockID, &tezos.BakingRightsOptions{Delegate: baker, Cycle: &cy, MaxRound: &maxRound})
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range rights {
+				levels = append(levels, r.Level)
+			}
+		}
+		sort.Ints(levels)
+		slots[baker] = levels
+	}
+	return slots, nil
+}
+
+// levelTime returns the expected time of the level assuming no missed blocks
+func (c *NextSlotCollector) levelTime(level int, delay time.Duration) time.Time {
+	return c.timestamp.Add(time.Duration(level-c.head) * delay)
+}
+
+// Gaps returns the upcoming spans between baking slots of all the watched bakers not shorter than min.
+// The last span ends at the end of the next cycle. Nil is returned unless the rights and constants are known.
+func (c *NextSlotCollector) Gaps(min time.Duration) []*SlotGap {
+	constants := c.follower.Constants()
+	if constants == nil || constants.MinimalBlockDelay == 0 {
+		return nil
+	}
+	delay := time.Duration(constants.MinimalBlockDelay) * time.Second
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.timestamp.IsZero() || len(c.slots) != len(c.bakers) {
+		return nil
+	}
+
+	var levels []int
+	for _, l := range c.slots {
+		levels = append(levels, l...)
+	}
+	sort.Ints(levels)
+
+	end := c.head
+	if constants.BlocksPerCycle != 0 {
+		end = c.start + 2*constants.BlocksPerCycle
+	}
+	levels = append(levels, end)
+
+	gaps := []*SlotGap{}
+	now := time.Now()
+	from := c.head
+	start := now
+	for _, level := range levels {
+		if level <= from {
+			continue
+		}
+		t := c.levelTime(level, delay)
+		if d := t.Sub(start); level > from+1 && d >= min && d > 0 {
+			gaps = append(gaps, &SlotGap{
+				FromLevel: from + 1,
+				ToLevel:   level - 1,
+				Start:     start,
+				End:       t,
+				Seconds:   d.Seconds(),
+			})
+		}
+		from = level
+		start = t
+	}
+	return gaps
+}
+
+// Describe implements prometheus.Collector
+func (c *NextSlotCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- nextBakingSlotLevelDesc
+	ch <- nextBakingSlotETADesc
+}
+
+// Collect implements prometheus.Collector
+func (c *NextSlotCollector) Collect(ch chan<- prometheus.Metric) {
+	var delay time.Duration
+	if constants := c.follower.Constants(); constants != nil {
+		delay = time.Duration(constants.MinimalBlockDelay) * time.Second
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now()
+	for baker, levels := range c.slots {
+		i := sort.SearchInts(levels, c.head+1)
+		if i == len(levels) {
+			continue
+		}
+		level := levels[i]
+		ch <- prometheus.MustNewConstMetric(nextBakingSlotLevelDesc, prometheus.GaugeValue, float64(level), baker)
+
+		if delay != 0 {
+			eta := c.levelTime(level, delay).Sub(now)
+			if eta < 0 {
+				eta = 0
+			}
+			ch <- prometheus.MustNewConstMetric(nextBakingSlotETADesc, prometheus.GaugeValue, eta.Seconds(), baker)
+		}
+	}
+}
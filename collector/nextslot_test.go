@@ -0,0 +1,180 @@
+package collector
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNextSlotCollector(t *testing.T, srv *tezostest.Server, bakers ...string) *NextSlotCollector {
+	service := newTestService(t, srv)
+	follower := NewBlockFollower(service, "main", time.Second, time.Hour, 0, nil, nil)
+	follower.constants = &tezos.Constants{BlocksPerCycle: 100, MinimalBlockDelay: 8}
+	return NewNextSlotCollector(service, time.Second, "main", follower, bakers)
+}
+
+// gatherSlots returns the reported next slot levels and ETAs by delegate
+func gatherSlots(t *testing.T, c *NextSlotCollector) (levels, etas map[string]float64) {
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(c))
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	levels, etas = make(map[string]float64), make(map[string]float64)
+	for _, f := range families {
+		dst := levels
+		if f.GetName() == "tezos_baker_next_baking_slot_eta_seconds" {
+			dst = etas
+		}
+		for _, m := range f.Metric {
+			dst[m.Label[0].GetValue()] = m.GetGauge().GetValue()
+		}
+	}
+	return levels, etas
+}
+
+func TestNextSlotCollector(t *testing.T) {
+	tests := []struct {
+		name   string
+		head   int
+		levels map[string]float64
+		etas   map[string]float64
+	}{
+		{
+			name:   "before the first slot",
+			head:   100,
+			levels: map[string]float64{"tz1a": 105, "tz1b": 130},
+			etas:   map[string]float64{"tz1a": 40, "tz1b": 240},
+		},
+		{
+			name:   "at the slot",
+			head:   105,
+			levels: map[string]float64{"tz1a": 110, "tz1b": 130},
+			etas:   map[string]float64{"tz1a": 40, "tz1b": 200},
+		},
+		{
+			name:   "no slots left",
+			head:   120,
+			levels: map[string]float64{"tz1b": 130},
+			etas:   map[string]float64{"tz1b": 80},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := tezostest.NewServer()
+			defer srv.Close()
+
+			c := newTestNextSlotCollector(t, srv, "tz1a", "tz1b")
+			c.slots = map[string][]int{"tz1a": {105, 110}, "tz1b": {130}}
+			c.head = test.head
+			c.timestamp = time.Now()
+
+			levels, etas := gatherSlots(t, c)
+			require.Equal(t, test.levels, levels)
+			require.Len(t, etas, len(test.etas))
+			for baker, eta := range test.etas {
+				require.InDelta(t, eta, etas[baker], 1, baker)
+			}
+		})
+	}
+}
+
+func TestNextSlotCollectorStaleCycle(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+	rights := func(level int) *tezostest.Response {
+		return &tezostest.Response{Body: []byte(`[{"level":` + strconv.Itoa(level) + `,"delegate":"tz1a","round":0}]`)}
+	}
+	// Cycles 1 and 2 requested for cycle 1, then cycles 2 and 3 requested for cycle 2
+	srv.Handle("/chains/main/blocks/*/helpers/baking_rights", rights(150), rights(250), rights(250), rights(350))
+
+	c := newTestNextSlotCollector(t, srv, "tz1a")
+	c.cycle = 2
+	c.slots = map[string][]int{"tz1a": {250}}
+
+	// The rights of the previous cycle arrive after the cycle has changed
+	c.update(1, "head")
+	require.Equal(t, map[string][]int{"tz1a": {250}}, c.slots)
+
+	c.update(2, "head")
+	require.Equal(t, map[string][]int{"tz1a": {250, 350}}, c.slots)
+}
+
+func TestNextSlotCollectorGaps(t *testing.T) {
+	type gap struct {
+		from, to int
+		seconds  float64
+	}
+	tests := []struct {
+		name   string
+		min    time.Duration
+		slots  map[string][]int
+		expect []gap
+	}{
+		{
+			name:   "rights unknown",
+			slots:  map[string][]int{"tz1a": {105}},
+			expect: nil,
+		},
+		{
+			name:  "all",
+			slots: map[string][]int{"tz1a": {100, 105, 110}, "tz1b": {111, 130}},
+			// The gap between the adjacent slots 110 and 111 is empty, the last one ends with the next cycle
+			expect: []gap{{101, 104, 41}, {106, 109, 40}, {112, 129, 152}, {131, 299, 1360}},
+		},
+		{
+			name:   "min duration inclusive",
+			min:    40 * time.Second,
+			slots:  map[string][]int{"tz1a": {100, 105, 110}, "tz1b": {111, 130}},
+			expect: []gap{{101, 104, 41}, {106, 109, 40}, {112, 129, 152}, {131, 299, 1360}},
+		},
+		{
+			name:   "min duration",
+			min:    45 * time.Second,
+			slots:  map[string][]int{"tz1a": {100, 105, 110}, "tz1b": {111, 130}},
+			expect: []gap{{112, 129, 152}, {131, 299, 1360}},
+		},
+		{
+			name:   "none",
+			min:    time.Hour,
+			slots:  map[string][]int{"tz1a": {100, 105, 110}, "tz1b": {111, 130}},
+			expect: []gap{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := tezostest.NewServer()
+			defer srv.Close()
+
+			// The repeated baker is watched once
+			c := newTestNextSlotCollector(t, srv, "tz1a", "tz1b", "tz1a")
+			c.slots = test.slots
+			c.head = 100
+			c.start = 100
+			c.timestamp = time.Now().Add(time.Second)
+
+			gaps := c.Gaps(test.min)
+			if test.expect == nil {
+				require.Nil(t, gaps)
+				return
+			}
+			require.NotNil(t, gaps)
+			require.Len(t, gaps, len(test.expect))
+			got := make([]gap, len(gaps))
+			for i, g := range gaps {
+				got[i] = gap{g.FromLevel, g.ToLevel, g.Seconds}
+				require.InDelta(t, test.expect[i].seconds, g.Seconds, 0.5)
+				require.Equal(t, g.Seconds, g.End.Sub(g.Start).Seconds())
+				got[i].seconds = test.expect[i].seconds
+			}
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package collector
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	stakingBalanceDesc = prometheus.NewDesc(
+		"tezos_node_baker_staking_balance_mutez",
+		"The total amount of tokens delegated to or staked with the baker, including its own balance.",
+		[]string{"baker"},
+		nil)
+
+	delegatedBalanceDesc = prometheus.NewDesc(
+		"tezos_node_baker_delegated_balance_mutez",
+		"The balance of all the contracts delegating to the baker, excluding its own balance.",
+		[]string{"baker"},
+		nil)
+)
+
+// Reward types
+const (
+	rewardBaking    = "baking"
+	rewardEndorsing = "endorsing"
+	rewardFees      = "fees"
+)
+
+// rewardSources maps balance update categories the rewards and fees are paid from to reward types
+var rewardSources = map[string]string{
+	"baking rewards":    rewardBaking,
+	"baking bonuses":    rewardBaking,
+	"endorsing rewards": rewardEndorsing,
+	"attesting rewards": rewardEndorsing,
+	"block fees":        rewardFees,
+}
+
+// PayoutCollector collects reward-bearing balance updates of the watched bakers keyed by cycle along with their delegated stake.
+// Every level is counted once
+type PayoutCollector struct {
+	service *tezos.Service
+	timeout time.Duration
+	chainID string
+	bakers  []string
+	window  cycleWindow
+	counted levelGuard
+	rewards *prometheus.CounterVec
+}
+
+// NewPayoutCollector returns a new PayoutCollector fed by the block follower. Only the last retention cycles are kept.
+func NewPayoutCollector(service *tezos.Service, timeout time.Duration, chainID string, follower *BlockFollower, bakers []string, retention int) *PayoutCollector {
+	c := &PayoutCollector{
+		service: service,
+		timeout: timeout,
+		chainID: chainID,
+		bakers:  bakers,
+		window:  cycleWindow{size: retention},
+		rewards: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "baker",
+				Name:      "cycle_rewards_mutez_total",
+				Help:      "The total amount of rewards and fees credited to the baker during the cycle.",
+			},
+			[]string{"baker", "cycle"},
+		),
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+// bakerRewards calls fn for every reward credited to the baker. Since Ithaca each credit follows the debit of its source,
+// before that the rewards were frozen directly and the type is given by legacy.
+func bakerRewards(updates tezos.BalanceUpdates, baker, legacy string, fn func(typ string, amount int64)) {
+	var source string
+	for _, u := range updates {
+		switch u := u.(type) {
+		case *tezos.CategoryBalanceUpdate:
+			source = ""
+			if u.Change < 0 {
+				source = rewardSources[u.Category]
+			}
+			continue
+
+		case *tezos.ContractBalanceUpdate:
+			if source != "" && u.Contract == baker && u.Change > 0 {
+				fn(source, u.Change)
+			}
+
+		case *tezos.FreezerBalanceUpdate:
+			if u.FreezerDelegate() == baker && u.Change > 0 {
+				switch {
+				case source != "":
+					fn(source, u.Change)
+				case u.Category == "rewards":
+					fn(legacy, u.Change)
+				case u.Category == "fees":
+					fn(rewardFees, u.Change)
+				}
+			}
+		}
+		source = ""
+	}
+}
+
+// blockRewards calls fn for every reward credited to the baker by the block and its operations
+func blockRewards(block *tezos.Block, baker string, fn func(typ string, amount int64)) {
+	bakerRewards(block.Metadata.BalanceUpdates, baker, rewardBaking, fn)
+	for _, pass := range block.Operations {
+		for _, op := range pass {
+			for _, elem := range op.Contents {
+				u, ok := elem.(tezos.BalanceUpdatesOperation)
+				if !ok {
+					continue
+				}
+				legacy := rewardBaking
+				if _, ok := elem.(*tezos.EndorsementOperationElem); ok {
+					legacy = rewardEndorsing
+				}
+				bakerRewards(u.BalanceUpdates(), baker, legacy, fn)
+			}
+		}
+	}
+}
+
+func (c *PayoutCollector) handleBlock(block *tezos.Block) {
+	if !block.HasMetadata() {
+		return
+	}
+	level := block.Metadata.CurrentLevel()
+	cycle := level.Cycle
+	evicted, ok := c.window.add(cycle)
+	for _, e := range evicted {
+		for _, baker := range c.bakers {
+			c.rewards.DeleteLabelValues(baker, strconv.Itoa(e))
+		}
+	}
+	if !ok || !c.counted.next(level.Level) {
+		return
+	}
+	label := strconv.Itoa(cycle)
+
+	for _, baker := range c.bakers {
+		var sum int64
+		blockRewards(block, baker, func(typ string, amount int64) { sum += amount })
+		c.rewards.WithLabelValues(baker, label).Add(float64(sum))
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *PayoutCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.rewards.Describe(ch)
+	ch <- stakingBalanceDesc
+	ch <- delegatedBalanceDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *PayoutCollector) Collect(ch chan<- prometheus.Metric) {
+	c.rewards.Collect(ch)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, baker := range c.bakers {
+		var val float64
+		staking, err := c.service.GetDelegateStakingBalance(ctx, c.chainID, "head", baker)
+		if err != nil {
+			log.WithError(err).WithField("baker", baker).Error("error getting staking balance")
+			val = 1
+		} else {
+			v, _ := new(big.Float).SetInt(staking).Float64()
+			ch <- prometheus.MustNewConstMetric(stakingBalanceDesc, prometheus.GaugeValue, v, baker)
+		}
+
+		delegated, err := c.service.GetDelegateDelegatedBalance(ctx, c.chainID, "head", baker)
+		if err != nil {
+			log.WithError(err).WithField("baker", baker).Error("error getting delegated balance")
+			val = 1
+		} else {
+			v, _ := new(big.Float).SetInt(delegated).Float64()
+			ch <- prometheus.MustNewConstMetric(delegatedBalanceDesc, prometheus.GaugeValue, v, baker)
+		}
+		ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/chains/"+c.chainID+"/blocks/head/context/delegates/"+baker)
+	}
+}
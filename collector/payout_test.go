@@ -0,0 +1,120 @@
+package collector
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/stretchr/testify/require"
+)
+
+type reward struct {
+	typ    string
+	amount int64
+}
+
+func TestBakerRewards(t *testing.T) {
+	tests := []struct {
+		name    string
+		updates string
+		legacy  string
+		expect  []reward
+	}{
+		{
+			name: "credited after the source debit",
+			updates: `[
+				{"kind": "minted", "category": "baking rewards", "change": "-100", "origin": "block"},
+				{"kind": "contract", "contract": "` + testBaker + `", "change": "100", "origin": "block"},
+				{"kind": "minted", "category": "baking bonuses", "change": "-20", "origin": "block"},
+				{"kind": "freezer", "category": "deposits", "staker": {"baker_own_stake": "` + testBaker + `"}, "change": "20", "origin": "block"},
+				{"kind": "accumulator", "category": "block fees", "change": "-5", "origin": "block"},
+				{"kind": "contract", "contract": "` + testBaker + `", "change": "5", "origin": "block"}
+			]`,
+			legacy: rewardBaking,
+			expect: []reward{{rewardBaking, 100}, {rewardBaking, 20}, {rewardFees, 5}},
+		},
+		{
+			name: "other baker",
+			updates: `[
+				{"kind": "minted", "category": "attesting rewards", "change": "-100", "origin": "block"},
+				{"kind": "contract", "contract": "` + testOther + `", "change": "100", "origin": "block"}
+			]`,
+			legacy: rewardBaking,
+		},
+		{
+			name: "credit without a source",
+			updates: `[
+				{"kind": "contract", "contract": "` + testBaker + `", "change": "-100", "origin": "block"},
+				{"kind": "contract", "contract": "` + testBaker + `", "change": "100", "origin": "block"}
+			]`,
+			legacy: rewardBaking,
+		},
+		{
+			name: "frozen before Ithaca",
+			updates: `[
+				{"kind": "contract", "contract": "` + testBaker + `", "change": "-512", "origin": "block"},
+				{"kind": "freezer", "category": "deposits", "delegate": "` + testBaker + `", "change": "512", "origin": "block"},
+				{"kind": "freezer", "category": "rewards", "delegate": "` + testBaker + `", "change": "40", "origin": "block"},
+				{"kind": "freezer", "category": "fees", "delegate": "` + testBaker + `", "change": "3", "origin": "block"}
+			]`,
+			legacy: rewardEndorsing,
+			expect: []reward{{rewardEndorsing, 40}, {rewardFees, 3}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var updates tezos.BalanceUpdates
+			require.NoError(t, json.Unmarshal([]byte(test.updates), &updates))
+
+			var got []reward
+			bakerRewards(updates, testBaker, test.legacy, func(typ string, amount int64) { got = append(got, reward{typ, amount}) })
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestPayoutCollector(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+	srv.Handle("/chains/main/blocks/head/context/delegates/"+testBaker+"/staking_balance", &tezostest.Response{Body: []byte(`"6000000000"`)})
+	srv.Handle("/chains/main/blocks/head/context/delegates/"+testBaker+"/delegated_balance", &tezostest.Response{Body: []byte(`"2000000000"`)})
+
+	c := NewPayoutCollector(newTestService(t, srv), time.Second, "main", newTestFollower(t, nil), []string{testBaker}, 2)
+
+	var block tezos.Block
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"metadata": {
+			"protocol": "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ",
+			"level_info": {"level": 1000, "cycle": 745},
+			"balance_updates": [
+				{"kind": "minted", "category": "baking rewards", "change": "-100", "origin": "block"},
+				{"kind": "contract", "contract": "`+testBaker+`", "change": "100", "origin": "block"}
+			]
+		},
+		"operations": [[{"contents": [{"kind": "attestation", "metadata": {"delegate": "`+testBaker+`", "balance_updates": [
+			{"kind": "minted", "category": "attesting rewards", "change": "-30", "origin": "block"},
+			{"kind": "contract", "contract": "`+testBaker+`", "change": "30", "origin": "block"}
+		]}}]}]]
+	}`), &block))
+	c.handleBlock(&block)
+	// The same head replayed on reconnect
+	c.handleBlock(&block)
+
+	requireMetrics(t, withRPCFailed{c}, `
+# HELP tezos_node_baker_cycle_rewards_mutez_total The total amount of rewards and fees credited to the baker during the cycle.
+# TYPE tezos_node_baker_cycle_rewards_mutez_total counter
+tezos_node_baker_cycle_rewards_mutez_total{baker="`+testBaker+`",cycle="745"} 130
+# HELP tezos_node_baker_delegated_balance_mutez The balance of all the contracts delegating to the baker, excluding its own balance.
+# TYPE tezos_node_baker_delegated_balance_mutez gauge
+tezos_node_baker_delegated_balance_mutez{baker="`+testBaker+`"} 2e+09
+# HELP tezos_node_baker_staking_balance_mutez The total amount of tokens delegated to or staked with the baker, including its own balance.
+# TYPE tezos_node_baker_staking_balance_mutez gauge
+tezos_node_baker_staking_balance_mutez{baker="`+testBaker+`"} 6e+09
+# HELP tezos_rpc_failed A gauge that is set to 1 when a metrics collection RPC failed during the current scrape, 0 otherwise.
+# TYPE tezos_rpc_failed gauge
+tezos_rpc_failed{rpc="/chains/main/blocks/head/context/delegates/`+testBaker+`"} 0
+`)
+}
@@ -0,0 +1,64 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	pendingOpsDesc = prometheus.NewDesc(
+		"tezos_node_mempool_pending_operations",
+		"Current number of operations in the mempool.",
+		[]string{"pool"},
+		nil)
+)
+
+// MempoolPendingCollector collects current mempool depth
+type MempoolPendingCollector struct {
+	service *tezos.Service
+	timeout time.Duration
+	chainID string
+}
+
+// NewMempoolPendingCollector returns a new MempoolPendingCollector.
+func NewMempoolPendingCollector(service *tezos.Service, timeout time.Duration, chainID string) *MempoolPendingCollector {
+	return &MempoolPendingCollector{
+		service: service,
+		timeout: timeout,
+		chainID: chainID,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *MempoolPendingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pendingOpsDesc
+}
+
+// Collect implements prometheus.Collector and is called by the Prometheus registry when collecting metrics.
+func (c *MempoolPendingCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	ops, err := c.service.GetMempoolPendingOperations(ctx, c.chainID)
+	var val float64
+	if err != nil {
+		log.WithError(err).Error("error getting mempool pending operations")
+		val = 1
+	} else {
+		pools := map[string]int{
+			"applied":        len(ops.Applied),
+			"refused":        len(ops.Refused),
+			"branch_refused": len(ops.BranchRefused),
+			"branch_delayed": len(ops.BranchDelayed),
+			"unprocessed":    len(ops.Unprocessed),
+		}
+		for pool, count := range pools {
+			ch <- prometheus.MustNewConstMetric(pendingOpsDesc, prometheus.GaugeValue, float64(count), pool)
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/chains/"+c.chainID+"/mempool/pending_operations")
+}
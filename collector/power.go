@@ -0,0 +1,108 @@
+package collector
+
+import (
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type endorsementPower interface {
+	Power() int
+}
+
+// EndorsementPowerCollector collects the endorsement and preendorsement power included into head blocks.
+// Preendorsements are only included by blocks proposed at a locked round so they are counted separately.
+type EndorsementPowerCollector struct {
+	follower        *BlockFollower
+	power           prometheus.Gauge
+	prePower        prometheus.Gauge
+	max             prometheus.Gauge
+	endorsements    prometheus.Counter
+	preendorsements prometheus.Counter
+}
+
+// NewEndorsementPowerCollector returns a new EndorsementPowerCollector fed by the block follower.
+func NewEndorsementPowerCollector(follower *BlockFollower) *EndorsementPowerCollector {
+	c := &EndorsementPowerCollector{
+		follower: follower,
+		power: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tezos_node",
+			Subsystem: "head",
+			Name:      "endorsement_power",
+			Help:      "Total endorsement (attestation) power included into the head block.",
+		}),
+		prePower: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tezos_node",
+			Subsystem: "head",
+			Name:      "preendorsement_power",
+			Help:      "Total preendorsement (preattestation) power included into the head block as the locked round proof.",
+		}),
+		max: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tezos_node",
+			Subsystem: "head",
+			Name:      "endorsement_power_max",
+			Help:      "Maximum endorsement (attestation) power which can be included into a block according to the protocol constants.",
+		}),
+		endorsements: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "tezos_node",
+			Subsystem: "head",
+			Name:      "endorsements_included_total",
+			Help:      "The total number of endorsement (attestation) operations included into head blocks.",
+		}),
+		preendorsements: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "tezos_node",
+			Subsystem: "head",
+			Name:      "preendorsements_included_total",
+			Help:      "The total number of preendorsement (preattestation) operations included into head blocks.",
+		}),
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+func (c *EndorsementPowerCollector) handleBlock(block *tezos.Block) {
+	if len(block.Operations) == 0 || !block.HasMetadata() {
+		return
+	}
+
+	// Consensus operations are in the first validation pass
+	var power, prePower, endorsements, preendorsements int
+	for _, op := range block.Operations[0] {
+		for _, elem := range op.Contents {
+			switch el := elem.(type) {
+			case endorsementPower:
+				power += el.Power()
+				endorsements++
+			case *tezos.PreendorsementOperationElem:
+				prePower += el.PreendorsementPower()
+				preendorsements++
+			}
+		}
+	}
+	c.power.Set(float64(power))
+	c.prePower.Set(float64(prePower))
+	c.endorsements.Add(float64(endorsements))
+	c.preendorsements.Add(float64(preendorsements))
+
+	if constants := c.follower.Constants(); constants != nil {
+		c.max.Set(float64(constants.MaxEndorsementPower()))
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *EndorsementPowerCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.power.Describe(ch)
+	c.prePower.Describe(ch)
+	c.max.Describe(ch)
+	c.endorsements.Describe(ch)
+	c.preendorsements.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *EndorsementPowerCollector) Collect(ch chan<- prometheus.Metric) {
+	c.power.Collect(ch)
+	c.prePower.Collect(ch)
+	c.max.Collect(ch)
+	c.endorsements.Collect(ch)
+	c.preendorsements.Collect(ch)
+}
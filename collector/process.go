@@ -0,0 +1,45 @@
+package collector
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
+)
+
+// NewNodeProcessCollector returns a process collector reporting resource usage of a co-located node process
+// identified either by PID file or by process name
+func NewNodeProcessCollector(pidFile, name string) prometheus.Collector {
+	var pidFn func() (int, error)
+	if pidFile != "" {
+		pidFn = prometheus.NewPidFileFn(pidFile)
+	} else {
+		pidFn = func() (int, error) {
+			return findProcess(name)
+		}
+	}
+
+	return prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{
+		PidFn:     pidFn,
+		Namespace: "tezos_node",
+	})
+}
+
+func findProcess(name string) (int, error) {
+	procs, err := procfs.AllProcs()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range procs {
+		if comm, err := p.Comm(); err == nil && comm == name {
+			return p.PID, nil
+		}
+		if exe, err := p.Executable(); err == nil && filepath.Base(exe) == name {
+			return p.PID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("process %q not found", name)
+}
@@ -0,0 +1,72 @@
+package collector
+
+import (
+	"sync"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	protocolInfoDesc = prometheus.NewDesc(
+		"tezos_node_protocol_info",
+		"Protocol of the current head block.",
+		[]string{"protocol", "next_protocol"},
+		nil)
+)
+
+// ProtocolCollector collects the head block protocol info
+type ProtocolCollector struct {
+	changes prometheus.Counter
+
+	mtx          sync.Mutex
+	protocol     string
+	nextProtocol string
+}
+
+// NewProtocolCollector returns a new ProtocolCollector fed by the block follower.
+func NewProtocolCollector(follower *BlockFollower) *ProtocolCollector {
+	c := &ProtocolCollector{
+		changes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "tezos_node",
+			Name:      "protocol_changes_total",
+			Help:      "The total number of observed head protocol changes.",
+		}),
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+func (c *ProtocolCollector) handleBlock(block *tezos.Block) {
+	if !block.HasMetadata() {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.protocol != "" && c.protocol != block.Metadata.Protocol {
+		c.changes.Inc()
+	}
+	c.protocol = block.Metadata.Protocol
+	c.nextProtocol = block.Metadata.NextProtocol
+}
+
+// Describe implements prometheus.Collector.
+func (c *ProtocolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- protocolInfoDesc
+	c.changes.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *ProtocolCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.Lock()
+	protocol, nextProtocol := c.protocol, c.nextProtocol
+	c.mtx.Unlock()
+
+	if protocol != "" {
+		ch <- prometheus.MustNewConstMetric(protocolInfoDesc, prometheus.GaugeValue, 1, protocol, nextProtocol)
+	}
+	c.changes.Collect(ch)
+}
@@ -0,0 +1,102 @@
+package collector
+
+import (
+	"sync"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	blocksPerMinuteDesc = prometheus.NewDesc(
+		"tezos_node_blocks_per_minute",
+		"Number of head blocks per minute over the sliding window.",
+		nil,
+		nil)
+
+	operationsPerMinuteDesc = prometheus.NewDesc(
+		"tezos_node_block_operations_per_minute",
+		"Number of operations included into head blocks per minute over the sliding window.",
+		[]string{"kind"},
+		nil)
+)
+
+type blockRecord struct {
+	t   time.Time
+	ops map[string]int
+}
+
+// RateCollector computes in-process block and operation rates for consumers without rate() capabilities
+type RateCollector struct {
+	window time.Duration
+	kinds  *KindLimiter
+
+	mtx    sync.Mutex
+	blocks []*blockRecord
+}
+
+// NewRateCollector returns a new RateCollector fed by the block follower. The window must be positive. Operation kind label values are passed through kinds.
+func NewRateCollector(follower *BlockFollower, window time.Duration, kinds *KindLimiter) *RateCollector {
+	c := &RateCollector{
+		window: window,
+		kinds:  kinds,
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+// expire drops records which are out of the window. Must be called with the lock held.
+func (c *RateCollector) expire(now time.Time) {
+	i := 0
+	for i < len(c.blocks) && now.Sub(c.blocks[i].t) > c.window {
+		i++
+	}
+	c.blocks = c.blocks[i:]
+}
+
+func (c *RateCollector) handleBlock(block *tezos.Block) {
+	rec := blockRecord{
+		t:   time.Now(),
+		ops: make(map[string]int),
+	}
+	for _, pass := range block.Operations {
+		for _, op := range pass {
+			for _, elem := range op.Contents {
+				rec.ops[c.kinds.Kind(elem.OperationElemKind())]++
+			}
+		}
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.expire(rec.t)
+	c.blocks = append(c.blocks, &rec)
+}
+
+// Describe implements prometheus.Collector
+func (c *RateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- blocksPerMinuteDesc
+	ch <- operationsPerMinuteDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *RateCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.Lock()
+	c.expire(time.Now())
+	ops := make(map[string]int)
+	for _, b := range c.blocks {
+		for kind, n := range b.ops {
+			ops[kind] += n
+		}
+	}
+	blocks := len(c.blocks)
+	c.mtx.Unlock()
+
+	scale := float64(time.Minute) / float64(c.window)
+	ch <- prometheus.MustNewConstMetric(blocksPerMinuteDesc, prometheus.GaugeValue, float64(blocks)*scale)
+	for kind, n := range ops {
+		ch <- prometheus.MustNewConstMetric(operationsPerMinuteDesc, prometheus.GaugeValue, float64(n)*scale, kind)
+	}
+}
@@ -0,0 +1,88 @@
+package collector
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+type nodeCounter struct {
+	value    float64
+	offset   float64 // sum of the values seen before resets
+	restarts int     // restarts detected by the time of the last observation
+}
+
+// RestartDetector detects node restarts by the node's monotonic counters going backwards. A restart resets all of them
+// so it's counted once no matter how many counters observed it. The counters are re-based to stay monotonic across resets.
+type RestartDetector struct {
+	counter prometheus.Counter
+	resets  *prometheus.CounterVec
+
+	mtx      sync.Mutex
+	restarts int
+	counters map[string]*nodeCounter
+}
+
+// NewRestartDetector returns a new RestartDetector.
+func NewRestartDetector() *RestartDetector {
+	return &RestartDetector{
+		counter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "tezos_node",
+			Name:      "restarts_detected_total",
+			Help:      "The total number of node restarts detected by the node's monotonic counters going backwards.",
+		}),
+		resets: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_exporter",
+				Name:      "node_counter_resets_total",
+				Help:      "The total number of times the node counter exported as the metric went backwards and was re-based to stay monotonic.",
+			},
+			[]string{"metric"},
+		),
+		counters: make(map[string]*nodeCounter),
+	}
+}
+
+// Observe records the current value of the node counter exported as the named metric and returns the re-based value.
+// A nil detector returns the value as is.
+func (d *RestartDetector) Observe(metric string, value float64) float64 {
+	if d == nil {
+		return value
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	c, ok := d.counters[metric]
+	if !ok {
+		d.counters[metric] = &nodeCounter{value: value, restarts: d.restarts}
+		return value
+	}
+
+	if value < c.value {
+		d.resets.WithLabelValues(metric).Inc()
+		c.offset += c.value
+		// Already counted if another counter observed a restart since this one was seen last time
+		if c.restarts == d.restarts {
+			log.WithField("metric", metric).Warn("node restart detected")
+			d.restarts++
+			d.counter.Inc()
+		}
+	}
+	c.value = value
+	c.restarts = d.restarts
+	return c.offset + value
+}
+
+// Describe implements prometheus.Collector
+func (d *RestartDetector) Describe(ch chan<- *prometheus.Desc) {
+	d.counter.Describe(ch)
+	d.resets.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (d *RestartDetector) Collect(ch chan<- prometheus.Metric) {
+	d.counter.Collect(ch)
+	d.resets.Collect(ch)
+}
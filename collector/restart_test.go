@@ -0,0 +1,62 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartDetector(t *testing.T) {
+	d := NewRestartDetector()
+
+	d.Observe("tezos_node_sent_bytes_total", 100)
+	d.Observe("tezos_node_recv_bytes_total", 200)
+	d.Observe("tezos_node_sent_bytes_total", 150)
+
+	// Both counters observe the same restart
+	d.Observe("tezos_node_sent_bytes_total", 10)
+	d.Observe("tezos_node_recv_bytes_total", 20)
+	requireMetrics(t, d, `
+# HELP tezos_node_restarts_detected_total The total number of node restarts detected by the node's monotonic counters going backwards.
+# TYPE tezos_node_restarts_detected_total counter
+tezos_node_restarts_detected_total 1
+`, "tezos_node_restarts_detected_total")
+
+	// The next restart is counted again
+	d.Observe("tezos_node_recv_bytes_total", 5)
+	d.Observe("tezos_node_sent_bytes_total", 1)
+	requireMetrics(t, d, `
+# HELP tezos_node_restarts_detected_total The total number of node restarts detected by the node's monotonic counters going backwards.
+# TYPE tezos_node_restarts_detected_total counter
+tezos_node_restarts_detected_total 2
+`, "tezos_node_restarts_detected_total")
+}
+
+func TestRestartDetectorRebase(t *testing.T) {
+	tests := []struct {
+		value, rebased float64
+	}{
+		{100, 100},
+		{150, 150},
+		{10, 160},
+		{40, 190},
+		{0, 190},
+		{5, 195},
+	}
+
+	d := NewRestartDetector()
+	for _, test := range tests {
+		require.Equal(t, test.rebased, d.Observe("tezos_node_sent_bytes_total", test.value), test.value)
+	}
+	require.Equal(t, float64(7), d.Observe("tezos_node_recv_bytes_total", 7))
+
+	requireMetrics(t, d, `
+# HELP tezos_exporter_node_counter_resets_total The total number of times the node counter exported as the metric went backwards and was re-based to stay monotonic.
+# TYPE tezos_exporter_node_counter_resets_total counter
+tezos_exporter_node_counter_resets_total{metric="tezos_node_sent_bytes_total"} 2
+`, "tezos_exporter_node_counter_resets_total")
+
+	// A nil detector passes the values through
+	var nilDetector *RestartDetector
+	require.Equal(t, float64(3), nilDetector.Observe("tezos_node_sent_bytes_total", 3))
+}
@@ -0,0 +1,41 @@
+package collector
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RetriesCollector counts RPC request retries
+type RetriesCollector struct {
+	counter *prometheus.CounterVec
+}
+
+// NewRetriesCollector returns a new RetriesCollector. Use its HandleRetry method as tezos.RPCClient.RetryHandler.
+func NewRetriesCollector() *RetriesCollector {
+	return &RetriesCollector{
+		counter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_rpc",
+				Name:      "retries_total",
+				Help:      "The total number of RPC requests retried after a connection error or a 5xx response.",
+			},
+			[]string{"endpoint"},
+		),
+	}
+}
+
+// HandleRetry counts the retry
+func (c *RetriesCollector) HandleRetry(req *http.Request, attempt int, err error) {
+	c.counter.WithLabelValues(rpcEndpoint(req.URL.Path)).Inc()
+}
+
+// Describe implements prometheus.Collector
+func (c *RetriesCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.counter.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *RetriesCollector) Collect(ch chan<- prometheus.Metric) {
+	c.counter.Collect(ch)
+}
@@ -0,0 +1,322 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// rewardParams holds the reward amounts in mutez
+type rewardParams struct {
+	fixed     float64
+	bonus     float64
+	endorsing float64
+}
+
+func bigIntFloat(v *tezos.BigInt) float64 {
+	if v == nil {
+		return 0
+	}
+	f, _ := new(big.Float).SetInt(&v.Int).Float64()
+	return f
+}
+
+// RewardsCollector exposes the estimated baking and endorsing rewards of the watched bakers for the current cycle
+// along with the realized ones and the endorsing rewards lost due to missed endorsements. Only the last retention cycles are kept.
+type RewardsCollector struct {
+	service  *tezos.Service
+	timeout  time.Duration
+	chainID  string
+	follower *BlockFollower
+	bakers   []string
+	window   cycleWindow
+	counted  levelGuard
+
+	estimated *prometheus.GaugeVec
+	realized  *prometheus.CounterVec
+	missed    *prometheus.CounterVec
+	lost      *prometheus.CounterVec
+
+	mtx         sync.Mutex
+	estimates   int // the last cycle estimates are requested for
+	endorsed    int // the last level endorsements are checked at
+	amountCycle int
+	amounts     *rewardParams // the reward amounts of amountCycle
+}
+
+// NewRewardsCollector returns a new RewardsCollector fed by the block follower.
+func NewRewardsCollector(service *tezos.Service, timeout time.Duration, chainID string, follower *BlockFollower, bakers []string, retention int) *RewardsCollector {
+	c := &RewardsCollector{
+		service:  service,
+		timeout:  timeout,
+		chainID:  chainID,
+		follower: follower,
+		bakers:   bakers,
+		window:   cycleWindow{size: retention},
+		estimated: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "tezos_node",
+				Subsystem: "baker",
+				Name:      "estimated_rewards_mutez",
+				Help:      "The rewards the baker is expected to receive during the cycle by type (baking or endorsing), computed from its rights assuming full participation.",
+			},
+			[]string{"baker", "cycle", "type"},
+		),
+		realized: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "baker",
+				Name:      "realized_rewards_mutez_total",
+				Help:      "The total amount of rewards credited to the baker during the cycle by type (baking or endorsing).",
+			},
+			[]string{"baker", "cycle", "type"},
+		),
+		missed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "baker",
+				Name:      "missed_endorsements_total",
+				Help:      "The total number of levels the baker had endorsing rights at but its endorsement wasn't included into the next head block.",
+			},
+			[]string{"baker", "cycle"},
+		),
+		lost: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Subsystem: "baker",
+				Name:      "lost_endorsing_rewards_mutez_total",
+				Help:      "The estimated endorsing rewards lost due to missed endorsements, computed as the missed endorsing power times the reward per slot.",
+			},
+			[]string{"baker", "cycle"},
+		),
+		estimates: -1,
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+func (c *RewardsCollector) handleBlock(block *tezos.Block) {
+	if !block.HasMetadata() {
+		return
+	}
+	cycle := block.Metadata.CurrentLevel().Cycle
+	evicted, ok := c.window.add(cycle)
+	for _, e := range evicted {
+		label := strconv.Itoa(e)
+		for _, baker := range c.bakers {
+			for _, typ := range []string{rewardBaking, rewardEndorsing} {
+				c.estimated.DeleteLabelValues(baker, label, typ)
+				c.realized.DeleteLabelValues(baker, label, typ)
+			}
+			c.missed.DeleteLabelValues(baker, label)
+			c.lost.DeleteLabelValues(baker, label)
+		}
+	}
+	if !ok {
+		return
+	}
+	label := strconv.Itoa(cycle)
+
+	if c.counted.next(block.Metadata.CurrentLevel().Level) {
+		for _, baker := range c.bakers {
+			blockRewards(block, baker, func(typ string, amount int64) {
+				if typ != rewardFees {
+					c.realized.WithLabelValues(baker, label, typ).Add(float64(amount))
+				}
+			})
+		}
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	// A level is checked once even if the block including its endorsements is replaced by a higher round
+	if level := block.Header.Level - 1; level > c.endorsed {
+		c.endorsed = level
+		go c.checkEndorsements(block, cycle)
+	}
+	if cycle != c.estimates {
+		c.estimates = cycle
+		go c.estimate(cycle, block.Hash)
+	}
+}
+
+// cycleParams returns the reward amounts for the cycle fetching them once per cycle
+func (c *RewardsCollector) cycleParams(ctx context.Context, cycle int, blockID string) (*rewardParams, error) {
+	c.mtx.Lock()
+	if c.amounts != nil && c.amountCycle == cycle {
+		params := c.amounts
+		c.mtx.Unlock()
+		return params, nil
+	}
+	c.mtx.Unlock()
+
+	params, err := c.params(ctx, cycle, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	c.amounts = params
+	c.amountCycle = cycle
+	c.mtx.Unlock()
+	return params, nil
+}
+
+// checkEndorsements compares the endorsing rights at the predecessor level with the endorsements included into the block
+func (c *RewardsCollector) checkEndorsements(block *tezos.Block, cycle int) {
+	if len(block.Operations) == 0 || block.Header.Level < 2 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	level := block.Header.Level - 1
+	rights, err := c.service.GetEndorsingRights(ctx, c.chainID, block.Hash, &tezos.EndorsingRightsOptions{Level: &level})
+	if err != nil {
+		log.WithError(err).WithField("level", level).Error("error getting endorsing rights")
+		return
+	}
+
+	expected := make(map[string]int)
+	for _, r := range rights {
+		if r.Level != level {
+			continue
+		}
+		for _, d := range r.Delegates {
+			expected[d.Delegate] = d.Power()
+		}
+	}
+
+	// Consensus operations are in the first validation pass
+	endorsed := make(map[string]bool)
+	for _, op := range block.Operations[0] {
+		for _, elem := range op.Contents {
+			if e, ok := elem.(*tezos.EndorsementOperationElem); ok && e.Level == level {
+				endorsed[e.Metadata.Delegate] = true
+			}
+		}
+	}
+
+	var params *rewardParams
+	label := strconv.Itoa(cycle)
+	for _, baker := range c.bakers {
+		power, ok := expected[baker]
+		if !ok || power == 0 || endorsed[baker] {
+			continue
+		}
+		c.missed.WithLabelValues(baker, label).Inc()
+
+		if params == nil {
+			if params, err = c.cycleParams(ctx, cycle, block.Hash); err != nil {
+				log.WithError(err).WithField("cycle", cycle).Error("error getting reward amounts")
+				return
+			}
+		}
+		c.lost.WithLabelValues(baker, label).Add(float64(power) * params.endorsing)
+	}
+}
+
+// params returns the reward amounts for the cycle
+func (c *RewardsCollector) params(ctx context.Context, cycle int, blockID string) (*rewardParams, error) {
+	// Oxford and later
+	if issuance, err := c.service.GetExpectedIssuance(ctx, c.chainID, blockID); err == nil {
+		for _, i := range issuance {
+			if i.Cycle == cycle {
+				return &rewardParams{
+					fixed:     bigIntFloat(i.BakingRewardFixedPortion),
+					bonus:     bigIntFloat(i.BakingRewardBonusPerSlot),
+					endorsing: bigIntFloat(i.AttestingRewardPerSlot),
+				}, nil
+			}
+		}
+	}
+
+	constants := c.follower.Constants()
+	if constants == nil || constants.BakingRewardFixedPortion == nil {
+		return nil, errors.New("reward amounts are not available")
+	}
+	return &rewardParams{
+		fixed:     bigIntFloat(constants.BakingRewardFixedPortion),
+		bonus:     bigIntFloat(constants.BakingRewardBonusPerSlot),
+		endorsing: bigIntFloat(constants.EndorsingRewardPerSlot),
+	}, nil
+}
+
+func (c *RewardsCollector) estimate(cycle int, blockID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	err := c.doEstimate(ctx, cycle, blockID)
+	if err != nil {
+		log.WithError(err).WithField("cycle", cycle).Error("error estimating rewards")
+		// Retry with the next block
+		c.mtx.Lock()
+		if c.estimates == cycle {
+			c.estimates = -1
+		}
+		c.mtx.Unlock()
+	}
+}
+
+func (c *RewardsCollector) doEstimate(ctx context.Context, cycle int, blockID string) error {
+	params, err := c.cycleParams(ctx, cycle, blockID)
+	if err != nil {
+		return err
+	}
+
+	var extraSlots float64
+	if constants := c.follower.Constants(); constants != nil {
+		extraSlots = float64(constants.ConsensusCommitteeSize - constants.ConsensusThreshold)
+	}
+
+	label := strconv.Itoa(cycle)
+	maxRound := 0
+	for _, baker := range c.bakers {
+		baking, err := c.service.GetBakingRights(ctx, c.chainID, blockID, &tezos.BakingRightsOptions{Delegate: baker, Cycle: &cycle, MaxRound: &maxRound})
+		if err != nil {
+			return err
+		}
+		endorsing, err := c.service.GetEndorsingRights(ctx, c.chainID, blockID, &tezos.EndorsingRightsOptions{Delegate: baker, Cycle: &cycle})
+		if err != nil {
+			return err
+		}
+
+		var power int
+		for _, r := range endorsing {
+			for _, d := range r.Delegates {
+				if d.Delegate == baker {
+					power += d.Power()
+				}
+			}
+		}
+
+		c.estimated.WithLabelValues(baker, label, rewardBaking).Set(float64(len(baking)) * (params.fixed + params.bonus*extraSlots))
+		c.estimated.WithLabelValues(baker, label, rewardEndorsing).Set(float64(power) * params.endorsing)
+	}
+	return nil
+}
+
+// Describe implements prometheus.Collector
+func (c *RewardsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.estimated.Describe(ch)
+	c.realized.Describe(ch)
+	c.missed.Describe(ch)
+	c.lost.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *RewardsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.estimated.Collect(ch)
+	c.realized.Collect(ch)
+	c.missed.Collect(ch)
+	c.lost.Collect(ch)
+}
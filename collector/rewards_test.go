@@ -0,0 +1,112 @@
+package collector
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBaker = "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	testOther = "tz1NortRftucvAkD1J58L32EhSVrQEWJCEnB"
+)
+
+func attestationsBlock(level int, delegates ...string) *tezos.Block {
+	ops := make([]*tezos.Operation, len(delegates))
+	for i, d := range delegates {
+		elem := &tezos.EndorsementOperationElem{Level: level - 1}
+		elem.Metadata.Delegate = d
+		ops[i] = &tezos.Operation{Contents: tezos.OperationElements{elem}}
+	}
+	block := &tezos.Block{Hash: "BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2", Operations: [][]*tezos.Operation{ops}}
+	block.Header.Level = level
+	block.Metadata.Protocol = "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ"
+	block.Metadata.Level.Level = level
+	block.Metadata.Level.Cycle = 745
+	return block
+}
+
+func newTestRewardsCollector(t *testing.T, srv *tezostest.Server) *RewardsCollector {
+	srv.Handle("/chains/main/blocks/*/helpers/endorsing_rights", &tezostest.Response{Body: []byte(`[{"level":99,"delegates":[
+		{"delegate":"` + testBaker + `","first_slot":0,"attestation_power":3},
+		{"delegate":"` + testOther + `","first_slot":3,"attestation_power":5}]}]`)})
+	srv.Handle("/chains/main/blocks/*/context/issuance/expected_issuance", tezostest.MustFixture(fixtures+"chains/expected_issuance.json"))
+
+	service := newTestService(t, srv)
+	follower := NewBlockFollower(service, "main", time.Second, time.Hour, 0, nil, nil)
+	c := NewRewardsCollector(service, time.Second, "main", follower, []string{testBaker}, 2)
+	// Skip the estimates
+	c.estimates = 745
+	return c
+}
+
+func TestRewardsCollectorEndorsements(t *testing.T) {
+	tests := []struct {
+		name     string
+		endorsed []string
+		expected string
+	}{
+		{
+			name:     "endorsed",
+			endorsed: []string{testOther, testBaker},
+		},
+		{
+			name:     "missed",
+			endorsed: []string{testOther},
+			expected: `
+# HELP tezos_node_baker_lost_endorsing_rewards_mutez_total The estimated endorsing rewards lost due to missed endorsements, computed as the missed endorsing power times the reward per slot.
+# TYPE tezos_node_baker_lost_endorsing_rewards_mutez_total counter
+tezos_node_baker_lost_endorsing_rewards_mutez_total{baker="` + testBaker + `",cycle="745"} 8196
+# HELP tezos_node_baker_missed_endorsements_total The total number of levels the baker had endorsing rights at but its endorsement wasn't included into the next head block.
+# TYPE tezos_node_baker_missed_endorsements_total counter
+tezos_node_baker_missed_endorsements_total{baker="` + testBaker + `",cycle="745"} 1
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := tezostest.NewServer()
+			defer srv.Close()
+
+			c := newTestRewardsCollector(t, srv)
+			c.checkEndorsements(attestationsBlock(100, test.endorsed...), 745)
+			require.Equal(t, 1, srv.Requests("/chains/main/blocks/*/helpers/endorsing_rights"))
+			require.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(test.expected),
+				"tezos_node_baker_missed_endorsements_total", "tezos_node_baker_lost_endorsing_rewards_mutez_total"))
+		})
+	}
+}
+
+func TestRewardsCollectorOncePerLevel(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	c := newTestRewardsCollector(t, srv)
+	block := attestationsBlock(100, testOther)
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"kind": "minted", "category": "baking rewards", "change": "-100", "origin": "block"},
+		{"kind": "contract", "contract": "`+testBaker+`", "change": "100", "origin": "block"}
+	]`), &block.Metadata.BalanceUpdates))
+	c.handleBlock(block)
+	// The same head replayed on reconnect
+	c.handleBlock(block)
+	// The head replaced by a higher round
+	c.handleBlock(attestationsBlock(100, testOther))
+
+	requireMetrics(t, c, `
+# HELP tezos_node_baker_missed_endorsements_total The total number of levels the baker had endorsing rights at but its endorsement wasn't included into the next head block.
+# TYPE tezos_node_baker_missed_endorsements_total counter
+tezos_node_baker_missed_endorsements_total{baker="`+testBaker+`",cycle="745"} 1
+# HELP tezos_node_baker_realized_rewards_mutez_total The total amount of rewards credited to the baker during the cycle by type (baking or endorsing).
+# TYPE tezos_node_baker_realized_rewards_mutez_total counter
+tezos_node_baker_realized_rewards_mutez_total{baker="`+testBaker+`",cycle="745",type="baking"} 100
+`, "tezos_node_baker_missed_endorsements_total", "tezos_node_baker_realized_rewards_mutez_total")
+	require.Equal(t, 1, srv.Requests("/chains/main/blocks/*/helpers/endorsing_rights"))
+}
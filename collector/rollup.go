@@ -0,0 +1,140 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// Rollup conditions
+const (
+	ConditionBootstrapped = "bootstrapped"
+	ConditionSynced       = "synced"
+	ConditionHeadFresh    = "head_fresh"
+	ConditionMinPeers     = "min_peers"
+)
+
+var (
+	nodeOKDesc = prometheus.NewDesc(
+		"tezos_node_ok",
+		"A gauge that is set to 1 when all configured health conditions are met, 0 otherwise.",
+		nil,
+		nil)
+
+	conditionFailedDesc = prometheus.NewDesc(
+		"tezos_node_ok_condition_failed",
+		"A gauge that is set to 1 when the health condition is not met, 0 otherwise.",
+		[]string{"condition"},
+		nil)
+)
+
+// RollupCollector exports a single boolean metric computed from a set of health conditions
+type RollupCollector struct {
+	service    *tezos.Service
+	timeout    time.Duration
+	chainID    string
+	conditions []string
+	maxHeadAge time.Duration
+	minPeers   int
+
+	mtx           sync.Mutex
+	headTimestamp time.Time
+}
+
+// NewRollupCollector returns a new RollupCollector checking the given conditions
+func NewRollupCollector(service *tezos.Service, timeout time.Duration, chainID string, follower *BlockFollower, conditions []string, maxHeadAge time.Duration, minPeers int) (*RollupCollector, error) {
+	var conds []string
+	for _, cond := range conditions {
+		switch cond {
+		case "":
+			continue
+		case ConditionBootstrapped, ConditionSynced, ConditionHeadFresh, ConditionMinPeers:
+			conds = append(conds, cond)
+		default:
+			return nil, fmt.Errorf("unknown health condition: %s", cond)
+		}
+	}
+
+	c := &RollupCollector{
+		service:    service,
+		timeout:    timeout,
+		chainID:    chainID,
+		conditions: conds,
+		maxHeadAge: maxHeadAge,
+		minPeers:   minPeers,
+	}
+
+	follower.SubscribeHeads(c.handleHead)
+	return c, nil
+}
+
+func (c *RollupCollector) handleHead(head *tezos.BlockInfo) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.headTimestamp = head.Timestamp
+}
+
+func (c *RollupCollector) check(ctx context.Context, cond string, status **tezos.BootstrappedStatus) (bool, error) {
+	switch cond {
+	case ConditionBootstrapped, ConditionSynced:
+		if *status == nil {
+			s, err := c.service.GetBootstrapped(ctx, c.chainID)
+			if err != nil {
+				return false, err
+			}
+			*status = s
+		}
+		if cond == ConditionBootstrapped {
+			return (*status).Bootstrapped, nil
+		}
+		return (*status).SyncState == tezos.SyncStateSynced, nil
+
+	case ConditionHeadFresh:
+		c.mtx.Lock()
+		ts := c.headTimestamp
+		c.mtx.Unlock()
+		return !ts.IsZero() && time.Since(ts) <= c.maxHeadAge, nil
+
+	case ConditionMinPeers:
+		conns, err := c.service.GetNetworkConnections(ctx)
+		if err != nil {
+			return false, err
+		}
+		return len(conns) >= c.minPeers, nil
+	}
+	return false, nil
+}
+
+// Describe implements prometheus.Collector.
+func (c *RollupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- nodeOKDesc
+	ch <- conditionFailedDesc
+}
+
+// Collect implements prometheus.Collector and is called by the Prometheus registry when collecting metrics.
+func (c *RollupCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var status *tezos.BootstrappedStatus
+	okAll := 1.0
+	for _, cond := range c.conditions {
+		ok, err := c.check(ctx, cond, &status)
+		if err != nil {
+			log.WithError(err).WithField("condition", cond).Error("error checking health condition")
+		}
+
+		var failed float64
+		if !ok {
+			failed = 1
+			okAll = 0
+		}
+		ch <- prometheus.MustNewConstMetric(conditionFailedDesc, prometheus.GaugeValue, failed, cond)
+	}
+	ch <- prometheus.MustNewConstMetric(nodeOKDesc, prometheus.GaugeValue, okAll)
+}
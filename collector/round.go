@@ -0,0 +1,62 @@
+package collector
+
+import (
+	"sync"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// BlockRoundCollector observes the rounds the chain blocks are agreed at. The round of a block is taken from the fitness
+// of its successor once a new level arrives, so the blocks replaced by a higher round at the same level aren't counted.
+type BlockRoundCollector struct {
+	follower *BlockFollower
+	hist     prometheus.Histogram
+
+	mtx   sync.Mutex
+	level int // the last observed level
+}
+
+// NewBlockRoundCollector returns a new BlockRoundCollector fed by the block follower.
+func NewBlockRoundCollector(follower *BlockFollower) *BlockRoundCollector {
+	c := &BlockRoundCollector{
+		follower: follower,
+		hist: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "tezos",
+			Subsystem: "chain",
+			Name:      "block_round",
+			Help:      "Distribution of Tenderbake rounds the chain blocks are agreed at. Sustained non zero rounds indicate network wide consensus degradation.",
+			Buckets:   []float64{0, 1, 2, 3, 5, 8},
+		}),
+	}
+
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+func (c *BlockRoundCollector) handleBlock(block *tezos.Block) {
+	round, ok := c.follower.Capabilities().PredecessorRound(&block.Header)
+	if !ok {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	level := block.Header.Level - 1
+	if level <= c.level {
+		return
+	}
+	c.level = level
+	c.hist.Observe(float64(round))
+}
+
+// Describe implements prometheus.Collector.
+func (c *BlockRoundCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.hist.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *BlockRoundCollector) Collect(ch chan<- prometheus.Metric) {
+	c.hist.Collect(ch)
+}
@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RPCCacheCollector counts RPC response cache hits and misses
+type RPCCacheCollector struct {
+	hits   *prometheus.CounterVec
+	misses *prometheus.CounterVec
+}
+
+// NewRPCCacheCollector returns a new RPCCacheCollector. Use its HandleCache method as tezos.RPCClient.CacheHandler.
+func NewRPCCacheCollector() *RPCCacheCollector {
+	return &RPCCacheCollector{
+		hits: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_rpc",
+				Name:      "cache_hits_total",
+				Help:      "The total number of RPC requests served from the response cache.",
+			},
+			[]string{"endpoint"},
+		),
+		misses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_rpc",
+				Name:      "cache_misses_total",
+				Help:      "The total number of cacheable RPC requests sent to the node.",
+			},
+			[]string{"endpoint"},
+		),
+	}
+}
+
+// HandleCache counts the cache lookup
+func (c *RPCCacheCollector) HandleCache(req *http.Request, hit bool) {
+	endpoint := rpcEndpointName(req.URL.Path)
+	if hit {
+		c.hits.WithLabelValues(endpoint).Inc()
+	} else {
+		c.misses.WithLabelValues(endpoint).Inc()
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *RPCCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.hits.Describe(ch)
+	c.misses.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *RPCCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	c.hits.Collect(ch)
+	c.misses.Collect(ch)
+}
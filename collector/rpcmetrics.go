@@ -0,0 +1,128 @@
+package collector
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RPCMetricsCollector is an RPC transport wrapper instrumenting every request with duration histograms and error counters
+// labeled by the endpoint name. The request duration is measured until the response headers arrive so streams are covered too.
+type RPCMetricsCollector struct {
+	transport http.RoundTripper
+	duration  *prometheus.HistogramVec
+	connect   *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+}
+
+// NewRPCMetricsCollector returns a new RPCMetricsCollector wrapping the transport. Nil transport means http.DefaultTransport.
+func NewRPCMetricsCollector(transport http.RoundTripper) *RPCMetricsCollector {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &RPCMetricsCollector{
+		transport: transport,
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "tezos_rpc",
+				Name:      "request_duration_seconds",
+				Help:      "RPC request duration until the response headers arrive.",
+				Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+			},
+			[]string{"endpoint"},
+		),
+		connect: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "tezos_rpc",
+				Name:      "connect_duration_seconds",
+				Help:      "Time until the RPC request gets a new or reused connection.",
+				Buckets:   prometheus.ExponentialBuckets(0.001, 2, 12),
+			},
+			[]string{"endpoint"},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_rpc",
+				Name:      "errors_total",
+				Help:      "The total number of failed RPC requests by HTTP status code, \"error\" stands for transport errors, and by class: timeout, canceled, connection_refused, connection, dns, tls, http_4xx, http_5xx or other.",
+			},
+			[]string{"endpoint", "code", "class"},
+		),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (c *RPCMetricsCollector) RoundTrip(r *http.Request) (*http.Response, error) {
+	endpoint := rpcEndpointName(r.URL.Path)
+	start := time.Now()
+	trace := &httptrace.ClientTrace{
+		GotConn: func(httptrace.GotConnInfo) {
+			c.connect.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+		},
+	}
+
+	resp, err := c.transport.RoundTrip(r.WithContext(httptrace.WithClientTrace(r.Context(), trace)))
+	c.duration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+	if err != nil {
+		c.errors.WithLabelValues(endpoint, "error", rpcErrorClass(err)).Inc()
+	} else if resp.StatusCode/100 != 2 {
+		c.errors.WithLabelValues(endpoint, strconv.Itoa(resp.StatusCode), fmt.Sprintf("http_%dxx", resp.StatusCode/100)).Inc()
+	}
+	return resp, err
+}
+
+// rpcErrorClass classifies transport errors by their likely remediation
+func rpcErrorClass(err error) string {
+	var (
+		netErr     net.Error
+		dnsErr     *net.DNSError
+		opErr      *net.OpError
+		recordErr  tls.RecordHeaderError
+		authErr    x509.UnknownAuthorityError
+		certErr    x509.CertificateInvalidError
+		hostErr    x509.HostnameError
+		systemRoot x509.SystemRootsError
+	)
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.As(err, &dnsErr):
+		return "dns"
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "connection_refused"
+	case errors.As(err, &recordErr), errors.As(err, &authErr), errors.As(err, &certErr), errors.As(err, &hostErr), errors.As(err, &systemRoot):
+		return "tls"
+	case errors.As(err, &opErr), errors.Is(err, syscall.ECONNRESET), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return "connection"
+	default:
+		return "other"
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *RPCMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.duration.Describe(ch)
+	c.connect.Describe(ch)
+	c.errors.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *RPCMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.duration.Collect(ch)
+	c.connect.Collect(ch)
+	c.errors.Collect(ch)
+}
@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPCErrorClass(t *testing.T) {
+	hold := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/network/stat":
+			<-hold
+		case "/network/connections":
+			http.Error(w, "Not Found", http.StatusNotFound)
+		default:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	defer close(hold)
+	tlsSrv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsSrv.Close()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	refused := "http://" + l.Addr().String()
+	l.Close()
+
+	c := NewRPCMetricsCollector(nil)
+	client := &http.Client{Transport: c}
+	get := func(url string, timeout time.Duration) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		require.NoError(t, err)
+		if resp, err := client.Do(req); err == nil {
+			resp.Body.Close()
+		}
+	}
+
+	get(srv.URL+"/network/stat", 50*time.Millisecond)
+	get(srv.URL+"/network/connections", time.Second)
+	get(srv.URL+"/network/peers", time.Second)
+	get(refused+"/network/points", time.Second)
+	get(tlsSrv.URL+"/chains/main/blocks/head", time.Second)
+
+	for _, tc := range []struct {
+		endpoint, code, class string
+	}{
+		{"network_stat", "error", "timeout"},
+		{"network_connections", "404", "http_4xx"},
+		{"network_peers", "500", "http_5xx"},
+		{"network_points", "error", "connection_refused"},
+		{"blocks", "error", "tls"},
+	} {
+		require.Equal(t, 1.0, testutil.ToFloat64(c.errors.WithLabelValues(tc.endpoint, tc.code, tc.class)), tc.endpoint)
+	}
+}
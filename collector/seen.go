@@ -0,0 +1,33 @@
+package collector
+
+import "sync"
+
+// seenSet remembers a fixed number of the last added operation hashes
+type seenSet struct {
+	mtx  sync.Mutex
+	seen map[string]struct{}
+	ring []string
+	pos  int
+}
+
+func newSeenSet(size int) *seenSet {
+	return &seenSet{
+		seen: make(map[string]struct{}, size),
+		ring: make([]string, size),
+	}
+}
+
+// add returns false if the hash was added already
+func (s *seenSet) add(hash string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.seen[hash]; ok {
+		return false
+	}
+	delete(s.seen, s.ring[s.pos])
+	s.ring[s.pos] = hash
+	s.pos = (s.pos + 1) % len(s.ring)
+	s.seen[hash] = struct{}{}
+	return true
+}
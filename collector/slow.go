@@ -0,0 +1,89 @@
+package collector
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+const slowRequestsLogInterval = time.Minute
+
+// SlowRequestsCollector is an RPC transport wrapper which counts and logs requests taking longer than the threshold.
+// The duration is measured until the response headers arrive so long living streams aren't reported.
+type SlowRequestsCollector struct {
+	threshold time.Duration
+	transport http.RoundTripper
+	counter   *prometheus.CounterVec
+
+	mtx        sync.Mutex
+	logged     map[string]time.Time
+	suppressed map[string]int
+}
+
+// NewSlowRequestsCollector returns a new SlowRequestsCollector wrapping the transport. Nil transport means http.DefaultTransport.
+func NewSlowRequestsCollector(transport http.RoundTripper, threshold time.Duration) *SlowRequestsCollector {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &SlowRequestsCollector{
+		threshold: threshold,
+		transport: transport,
+		counter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_rpc",
+				Name:      "slow_requests_total",
+				Help:      "The total number of RPC requests that exceeded the slow request threshold.",
+			},
+			[]string{"endpoint"},
+		),
+		logged:     make(map[string]time.Time),
+		suppressed: make(map[string]int),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (c *SlowRequestsCollector) RoundTrip(r *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.transport.RoundTrip(r)
+	if d := time.Since(start); d > c.threshold {
+		endpoint := rpcEndpoint(r.URL.Path)
+		c.counter.WithLabelValues(endpoint).Inc()
+		c.logSlow(endpoint, d)
+	}
+	return resp, err
+}
+
+// logSlow logs at most one message per endpoint per slowRequestsLogInterval
+func (c *SlowRequestsCollector) logSlow(endpoint string, d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.logged[endpoint]) < slowRequestsLogInterval {
+		c.suppressed[endpoint]++
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"endpoint":   endpoint,
+		"duration":   d,
+		"suppressed": c.suppressed[endpoint],
+	}).Warn("slow RPC request")
+
+	c.logged[endpoint] = now
+	c.suppressed[endpoint] = 0
+}
+
+// Describe implements prometheus.Collector
+func (c *SlowRequestsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.counter.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *SlowRequestsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.counter.Collect(ch)
+}
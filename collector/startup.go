@@ -0,0 +1,110 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	startupGateOpenDesc = prometheus.NewDesc(
+		"tezos_exporter_monitors_started",
+		"Whether the stream monitors were started after the startup delay and the node readiness check.",
+		nil,
+		nil)
+)
+
+// StartupGate holds back stream monitors until the startup delay elapses and, if enabled, the node RPC answers,
+// so a node starting alongside the exporter isn't hammered. Nil gate is always open.
+type StartupGate struct {
+	ready chan struct{}
+}
+
+// NewStartupGate returns a new StartupGate. With wait set the node is probed every interval after the delay until it answers.
+func NewStartupGate(service *tezos.Service, chainID string, delay time.Duration, wait bool, timeout, interval time.Duration) *StartupGate {
+	g := &StartupGate{
+		ready: make(chan struct{}),
+	}
+	go g.open(service, chainID, delay, wait, timeout, interval)
+	return g
+}
+
+func (g *StartupGate) open(service *tezos.Service, chainID string, delay time.Duration, wait bool, timeout, interval time.Duration) {
+	if delay > 0 {
+		log.WithField("delay", delay).Info("delaying monitors startup")
+		<-time.After(delay)
+	}
+
+	for wait {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		_, err := service.GetBootstrapped(ctx, chainID)
+		cancel()
+		if err == nil {
+			break
+		}
+		log.WithError(err).Info("waiting for the node to become ready")
+		<-time.After(interval)
+	}
+
+	log.Info("starting monitors")
+	close(g.ready)
+}
+
+// Wait blocks until the gate opens
+func (g *StartupGate) Wait() {
+	if g != nil {
+		<-g.ready
+	}
+}
+
+// Open returns true if the gate is open
+func (g *StartupGate) Open() bool {
+	if g == nil {
+		return true
+	}
+	select {
+	case <-g.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// Describe implements prometheus.Collector
+func (g *StartupGate) Describe(ch chan<- *prometheus.Desc) {
+	ch <- startupGateOpenDesc
+}
+
+// Collect implements prometheus.Collector
+func (g *StartupGate) Collect(ch chan<- prometheus.Metric) {
+	var v float64
+	if g.Open() {
+		v = 1
+	}
+	ch <- prometheus.MustNewConstMetric(startupGateOpenDesc, prometheus.GaugeValue, v)
+}
+
+type gatedCollector struct {
+	prometheus.Collector
+	gate *StartupGate
+}
+
+func (c *gatedCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.gate.Open() {
+		c.Collector.Collect(ch)
+	}
+}
+
+// Gated returns the scrape time collector reporting nothing until the gate opens
+func Gated(gate *StartupGate, c prometheus.Collector) prometheus.Collector {
+	if gate == nil {
+		return c
+	}
+	return &gatedCollector{
+		Collector: c,
+		gate:      gate,
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+// +build !windows
+
+package collector
+
+import "syscall"
+
+func statFS(path string) (*fsStats, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return nil, err
+	}
+
+	return &fsStats{
+		size:  uint64(st.Blocks) * uint64(st.Bsize),
+		avail: uint64(st.Bavail) * uint64(st.Bsize),
+	}, nil
+}
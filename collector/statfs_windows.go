@@ -0,0 +1,23 @@
+//go:build windows
+// +build windows
+
+package collector
+
+import "golang.org/x/sys/windows"
+
+func statFS(path string) (*fsStats, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var avail, size, free uint64
+	if err := windows.GetDiskFreeSpaceEx(p, &avail, &size, &free); err != nil {
+		return nil, err
+	}
+
+	return &fsStats{
+		size:  size,
+		avail: avail,
+	}, nil
+}
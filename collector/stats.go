@@ -0,0 +1,110 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+func newGCDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc("tezos_node_gc_"+name, help, nil, nil)
+}
+
+var (
+	gcMinorWordsDesc       = newGCDesc("minor_words_total", "Number of words allocated in the minor heap since the node was started.")
+	gcPromotedWordsDesc    = newGCDesc("promoted_words_total", "Number of words allocated in the minor heap that survived a minor collection and were moved to the major heap.")
+	gcMajorWordsDesc       = newGCDesc("major_words_total", "Number of words allocated in the major heap, including the promoted words.")
+	gcMinorCollectionsDesc = newGCDesc("minor_collections_total", "Number of minor collections since the node was started.")
+	gcMajorCollectionsDesc = newGCDesc("major_collections_total", "Number of major collection cycles completed since the node was started.")
+	gcCompactionsDesc      = newGCDesc("compactions_total", "Number of heap compactions since the node was started.")
+	gcHeapWordsDesc        = newGCDesc("heap_words", "Total size of the major heap, in words.")
+	gcHeapChunksDesc       = newGCDesc("heap_chunks", "Number of contiguous pieces of memory that make up the major heap.")
+	gcLiveWordsDesc        = newGCDesc("live_words", "Number of words of live data in the major heap, including the header words.")
+	gcFreeWordsDesc        = newGCDesc("free_words", "Number of words in the free list.")
+	gcTopHeapWordsDesc     = newGCDesc("top_heap_words", "Maximum size reached by the major heap, in words.")
+)
+
+func newMemoryDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc("tezos_node_memory_"+name, help, nil, nil)
+}
+
+var (
+	memVirtualDesc  = newMemoryDesc("virtual_bytes", "Virtual memory size of the node process.")
+	memResidentDesc = newMemoryDesc("resident_bytes", "Resident memory size of the node process.")
+	memSharedDesc   = newMemoryDesc("shared_bytes", "Resident shared memory size of the node process.")
+)
+
+// NodeStatsCollector collects the node's runtime statistics
+type NodeStatsCollector struct {
+	service  *tezos.Service
+	timeout  time.Duration
+	restarts *RestartDetector
+}
+
+// NewNodeStatsCollector returns a new NodeStatsCollector. GC counters are re-based by restarts if not nil.
+func NewNodeStatsCollector(service *tezos.Service, timeout time.Duration, restarts *RestartDetector) *NodeStatsCollector {
+	return &NodeStatsCollector{
+		service:  service,
+		timeout:  timeout,
+		restarts: restarts,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *NodeStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- gcMinorWordsDesc
+	ch <- gcPromotedWordsDesc
+	ch <- gcMajorWordsDesc
+	ch <- gcMinorCollectionsDesc
+	ch <- gcMajorCollectionsDesc
+	ch <- gcCompactionsDesc
+	ch <- gcHeapWordsDesc
+	ch <- gcHeapChunksDesc
+	ch <- gcLiveWordsDesc
+	ch <- gcFreeWordsDesc
+	ch <- gcTopHeapWordsDesc
+	ch <- memVirtualDesc
+	ch <- memResidentDesc
+	ch <- memSharedDesc
+}
+
+// Collect implements prometheus.Collector and is called by the Prometheus registry when collecting metrics.
+func (c *NodeStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	gc, err := c.service.GetGCStats(ctx)
+	var val float64
+	if err != nil {
+		log.WithError(err).Error("error getting GC stats")
+		val = 1
+	} else {
+		ch <- prometheus.MustNewConstMetric(gcMinorWordsDesc, prometheus.CounterValue, c.restarts.Observe("tezos_node_gc_minor_words_total", gc.MinorWords))
+		ch <- prometheus.MustNewConstMetric(gcPromotedWordsDesc, prometheus.CounterValue, c.restarts.Observe("tezos_node_gc_promoted_words_total", gc.PromotedWords))
+		ch <- prometheus.MustNewConstMetric(gcMajorWordsDesc, prometheus.CounterValue, c.restarts.Observe("tezos_node_gc_major_words_total", gc.MajorWords))
+		ch <- prometheus.MustNewConstMetric(gcMinorCollectionsDesc, prometheus.CounterValue, c.restarts.Observe("tezos_node_gc_minor_collections_total", float64(gc.MinorCollections)))
+		ch <- prometheus.MustNewConstMetric(gcMajorCollectionsDesc, prometheus.CounterValue, c.restarts.Observe("tezos_node_gc_major_collections_total", float64(gc.MajorCollections)))
+		ch <- prometheus.MustNewConstMetric(gcCompactionsDesc, prometheus.CounterValue, c.restarts.Observe("tezos_node_gc_compactions_total", float64(gc.Compactions)))
+		ch <- prometheus.MustNewConstMetric(gcHeapWordsDesc, prometheus.GaugeValue, float64(gc.HeapWords))
+		ch <- prometheus.MustNewConstMetric(gcHeapChunksDesc, prometheus.GaugeValue, float64(gc.HeapChunks))
+		ch <- prometheus.MustNewConstMetric(gcLiveWordsDesc, prometheus.GaugeValue, float64(gc.LiveWords))
+		ch <- prometheus.MustNewConstMetric(gcFreeWordsDesc, prometheus.GaugeValue, float64(gc.FreeWords))
+		ch <- prometheus.MustNewConstMetric(gcTopHeapWordsDesc, prometheus.GaugeValue, float64(gc.TopHeapWords))
+	}
+	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/stats/gc")
+
+	mem, err := c.service.GetMemoryStats(ctx)
+	if err != nil {
+		log.WithError(err).Error("error getting memory stats")
+		val = 1
+	} else {
+		ch <- prometheus.MustNewConstMetric(memVirtualDesc, prometheus.GaugeValue, float64(mem.Size*mem.PageSize))
+		ch <- prometheus.MustNewConstMetric(memResidentDesc, prometheus.GaugeValue, float64(mem.Resident*mem.PageSize))
+		ch <- prometheus.MustNewConstMetric(memSharedDesc, prometheus.GaugeValue, float64(mem.Shared*mem.PageSize))
+		val = 0
+	}
+	ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, "/stats/memory")
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	connTargetDesc = prometheus.NewDesc(
+		"tezos_node_connections_target",
+		"The node's configured connection limits by the bound (min, expected or max).",
+		[]string{"bound"},
+		nil)
+)
+
+// ConnectionTargets holds the node's peer maintenance limits
+type ConnectionTargets struct {
+	Min      int
+	Expected int
+	Max      int
+}
+
+// ConnectionTargetsFromExpected returns the limits derived from the expected connections number the same way the node's --connections option does
+func ConnectionTargetsFromExpected(expected int) *ConnectionTargets {
+	return &ConnectionTargets{
+		Min:      expected / 2,
+		Expected: expected,
+		Max:      3 * expected / 2,
+	}
+}
+
+// ConnectionTargetsCollector exposes the node's connection limits so they can be compared with the current connection count
+type ConnectionTargetsCollector struct {
+	targets ConnectionTargets
+}
+
+// NewConnectionTargetsCollector returns a new ConnectionTargetsCollector.
+func NewConnectionTargetsCollector(targets *ConnectionTargets) *ConnectionTargetsCollector {
+	return &ConnectionTargetsCollector{targets: *targets}
+}
+
+// Describe implements prometheus.Collector
+func (c *ConnectionTargetsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- connTargetDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *ConnectionTargetsCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(connTargetDesc, prometheus.GaugeValue, float64(c.targets.Min), "min")
+	ch <- prometheus.MustNewConstMetric(connTargetDesc, prometheus.GaugeValue, float64(c.targets.Expected), "expected")
+	ch <- prometheus.MustNewConstMetric(connTargetDesc, prometheus.GaugeValue, float64(c.targets.Max), "max")
+}
@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// UnknownFieldsCollector counts RPC responses containing fields unknown to go-tezos types
+type UnknownFieldsCollector struct {
+	counter *prometheus.CounterVec
+
+	mtx    sync.Mutex
+	logged map[[2]string]struct{}
+}
+
+// NewUnknownFieldsCollector returns a new UnknownFieldsCollector. Use its HandleUnknownField method as tezos.RPCClient.UnknownFieldHandler.
+func NewUnknownFieldsCollector() *UnknownFieldsCollector {
+	return &UnknownFieldsCollector{
+		counter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_exporter",
+				Name:      "unknown_fields_total",
+				Help:      "The total number of RPC responses containing fields unknown to the exporter.",
+			},
+			[]string{"type"},
+		),
+		logged: make(map[[2]string]struct{}),
+	}
+}
+
+// HandleUnknownField counts the response and logs every new type and field pair once
+func (c *UnknownFieldsCollector) HandleUnknownField(typ, field string) {
+	c.counter.WithLabelValues(typ).Inc()
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	key := [2]string{typ, field}
+	if _, ok := c.logged[key]; !ok {
+		c.logged[key] = struct{}{}
+		log.WithFields(log.Fields{"type": typ, "field": field}).Warn("unknown field in RPC response")
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *UnknownFieldsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.counter.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *UnknownFieldsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.counter.Collect(ch)
+}
@@ -0,0 +1,47 @@
+package collector
+
+import (
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UnknownOperationKindsCollector counts operation elements of kinds go-tezos has no dedicated type for
+type UnknownOperationKindsCollector struct {
+	counter *prometheus.CounterVec
+	kinds   *KindLimiter
+}
+
+// NewUnknownOperationKindsCollector returns a new UnknownOperationKindsCollector. Kind label values are passed through kinds.
+func NewUnknownOperationKindsCollector(kinds *KindLimiter) *UnknownOperationKindsCollector {
+	return &UnknownOperationKindsCollector{
+		counter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_exporter",
+				Name:      "unknown_operation_kinds_total",
+				Help:      "The total number of operations of kinds not known to the decoder.",
+			},
+			[]string{"kind"},
+		),
+		kinds: kinds,
+	}
+}
+
+// Observe counts the operation element if it was decoded as a generic one. Nil receiver is a no-op.
+func (c *UnknownOperationKindsCollector) Observe(elem tezos.OperationElem) {
+	if c == nil {
+		return
+	}
+	if _, ok := elem.(*tezos.GenericOperationElem); ok {
+		c.counter.WithLabelValues(c.kinds.Kind(elem.OperationElemKind())).Inc()
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *UnknownOperationKindsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.counter.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *UnknownOperationKindsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.counter.Collect(ch)
+}
@@ -0,0 +1,170 @@
+package collector
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// validBlocksDepth is the number of blocks on top of the level after which the validated blocks are checked against the main branch
+const validBlocksDepth = 2
+
+// validBlocksHistory is the number of last main branch levels remembered to check late validated blocks against
+const validBlocksHistory = 60
+
+// ValidBlocksCollector consumes the valid blocks stream and counts validated blocks which never became part of the main branch
+type ValidBlocksCollector struct {
+	service   *tezos.Service
+	chainID   string
+	interval  time.Duration
+	lag       *StreamLagCollector
+	gate      *StartupGate
+	valid     *prometheus.CounterVec
+	alternate *prometheus.CounterVec
+
+	mtx       sync.Mutex
+	head      int
+	protocols map[int]string         // protocol index to protocol hash
+	canonical map[int]string         // level to main branch block hash
+	pending   map[int]map[string]int // level to validated block hashes with their protocol indices
+}
+
+// NewValidBlocksCollector returns a new ValidBlocksCollector. The main branch is learned from the block follower.
+// The stream lag is reported to lag if not nil. Monitoring starts once the gate opens.
+func NewValidBlocksCollector(service *tezos.Service, chainID string, interval time.Duration, follower *BlockFollower, lag *StreamLagCollector, gate *StartupGate) *ValidBlocksCollector {
+	c := &ValidBlocksCollector{
+		service:  service,
+		chainID:  chainID,
+		interval: interval,
+		lag:      lag,
+		gate:     gate,
+		valid: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Name:      "valid_blocks_total",
+				Help:      "The total number of blocks validated by the node.",
+			},
+			[]string{"protocol"},
+		),
+		alternate: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "tezos_node",
+				Name:      "alternate_blocks_total",
+				Help:      "The total number of validated blocks which never became part of the main branch.",
+			},
+			[]string{"protocol"},
+		),
+		protocols: make(map[int]string),
+		canonical: make(map[int]string),
+		pending:   make(map[int]map[string]int),
+	}
+
+	follower.SubscribeHeads(c.handleHead)
+	follower.Subscribe(c.handleBlock)
+
+	log.WithField("chain", chainID).Info("starting valid blocks monitor")
+	go c.listener()
+	return c
+}
+
+// protocol must be called with the lock held
+func (c *ValidBlocksCollector) protocol(proto int) string {
+	if p, ok := c.protocols[proto]; ok {
+		return p
+	}
+	return "unknown"
+}
+
+func (c *ValidBlocksCollector) handleBlock(block *tezos.Block) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.protocols[block.Header.Proto] = block.Protocol
+}
+
+func (c *ValidBlocksCollector) handleHead(head *tezos.BlockInfo) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.head = head.Level
+	c.canonical[head.Level] = head.Hash
+	c.canonical[head.Level-1] = head.Predecessor
+	c.resolve()
+}
+
+func (c *ValidBlocksCollector) handleValidBlock(block *tezos.ValidBlock) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.valid.WithLabelValues(c.protocol(block.Proto)).Inc()
+	blocks, ok := c.pending[block.Level]
+	if !ok {
+		blocks = make(map[string]int)
+		c.pending[block.Level] = blocks
+	}
+	blocks[block.Hash] = block.Proto
+	c.resolve()
+}
+
+// resolve checks the validated blocks deep enough against the main branch. Must be called with the lock held.
+func (c *ValidBlocksCollector) resolve() {
+	if c.head == 0 {
+		return
+	}
+	for level, blocks := range c.pending {
+		if level > c.head-validBlocksDepth {
+			continue
+		}
+		// The main branch block is unknown if the follower skipped the level
+		if hash, ok := c.canonical[level]; ok {
+			for h, proto := range blocks {
+				if h != hash {
+					c.alternate.WithLabelValues(c.protocol(proto)).Inc()
+				}
+			}
+		}
+		delete(c.pending, level)
+	}
+	for level := range c.canonical {
+		if level <= c.head-validBlocksHistory {
+			delete(c.canonical, level)
+		}
+	}
+}
+
+func (c *ValidBlocksCollector) listener() {
+	c.gate.Wait()
+
+	ch := make(chan *tezos.ValidBlock, 100)
+	defer close(ch)
+
+	go func() {
+		for block := range ch {
+			c.lag.Observe("/monitor/valid_blocks", block.Timestamp)
+			c.handleValidBlock(block)
+		}
+	}()
+
+	for {
+		err := c.service.MonitorValidBlocks(context.Background(), c.chainID, "", ch)
+		if err != nil {
+			log.WithError(err).Error("error monitoring valid blocks")
+			<-time.After(c.interval)
+		}
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *ValidBlocksCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.valid.Describe(ch)
+	c.alternate.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *ValidBlocksCollector) Collect(ch chan<- prometheus.Metric) {
+	c.valid.Collect(ch)
+	c.alternate.Collect(ch)
+}
@@ -0,0 +1,41 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+)
+
+func TestValidBlocksCollector(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	// Both blocks are validated at the same level, the first one becomes part of the main branch
+	valid := tezostest.MustFixture(fixtures + "monitor/valid_blocks.chunked")
+	valid.Delay = 100 * time.Millisecond
+	valid.Hold = true
+	srv.Handle("/monitor/valid_blocks", valid)
+
+	c := NewValidBlocksCollector(newTestService(t, srv), "main", 10*time.Millisecond, newTestFollower(t, nil), nil, nil)
+	c.handleBlock(&tezos.Block{Protocol: "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ", Header: tezos.RawBlockHeader{Proto: 19}})
+	c.handleHead(&tezos.BlockInfo{Level: 5726210, Hash: "BLbHs8wMBdFTGyLcRwBSa6SkSE6BhbyNWFNeDodrtTdiNWbyy9c", Predecessor: "BLrrfnbYgCt1XnZGmhEg8pygAWhDQB1dj2Q6ogzx6yS5fnvbsWP"})
+
+	// The level isn't deep enough to be checked yet
+	requireMetrics(t, c, `
+# HELP tezos_node_valid_blocks_total The total number of blocks validated by the node.
+# TYPE tezos_node_valid_blocks_total counter
+tezos_node_valid_blocks_total{protocol="PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ"} 2
+`)
+
+	c.handleHead(&tezos.BlockInfo{Level: 5726211, Hash: "BMDZzKBpQGBgpKe2VspN3kz1ENRxqE2yM4thHwGVN8kuVAjQfrC", Predecessor: "BLbHs8wMBdFTGyLcRwBSa6SkSE6BhbyNWFNeDodrtTdiNWbyy9c"})
+	requireMetrics(t, c, `
+# HELP tezos_node_alternate_blocks_total The total number of validated blocks which never became part of the main branch.
+# TYPE tezos_node_alternate_blocks_total counter
+tezos_node_alternate_blocks_total{protocol="PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ"} 1
+# HELP tezos_node_valid_blocks_total The total number of blocks validated by the node.
+# TYPE tezos_node_valid_blocks_total counter
+tezos_node_valid_blocks_total{protocol="PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ"} 2
+`)
+}
@@ -0,0 +1,81 @@
+package collector
+
+import (
+	"sync"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	votingPeriodIndexDesc = prometheus.NewDesc(
+		"tezos_node_voting_period_index",
+		"Index of the voting period of the head block.",
+		nil,
+		nil)
+
+	votingPeriodPositionDesc = prometheus.NewDesc(
+		"tezos_node_voting_period_position",
+		"Position of the head block within its voting period, starting from zero.",
+		nil,
+		nil)
+
+	votingPeriodRemainingDesc = prometheus.NewDesc(
+		"tezos_node_voting_period_remaining_blocks",
+		"Number of blocks remaining until the end of the voting period of the head block.",
+		nil,
+		nil)
+
+	votingPeriodInfoDesc = prometheus.NewDesc(
+		"tezos_node_voting_period_info",
+		"Kind of the voting period of the head block.",
+		[]string{"kind"},
+		nil)
+)
+
+// VotingCollector collects the voting period progress from the head block metadata
+type VotingCollector struct {
+	mtx  sync.Mutex
+	info *tezos.VotingPeriodInfo
+}
+
+// NewVotingCollector returns a new VotingCollector fed by the block follower.
+func NewVotingCollector(follower *BlockFollower) *VotingCollector {
+	c := &VotingCollector{}
+	follower.Subscribe(c.handleBlock)
+	return c
+}
+
+func (c *VotingCollector) handleBlock(block *tezos.Block) {
+	// Missing before Florence
+	if block.Metadata.VotingPeriodInfo == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.info = block.Metadata.VotingPeriodInfo
+}
+
+// Describe implements prometheus.Collector.
+func (c *VotingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- votingPeriodIndexDesc
+	ch <- votingPeriodPositionDesc
+	ch <- votingPeriodRemainingDesc
+	ch <- votingPeriodInfoDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *VotingCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.Lock()
+	info := c.info
+	c.mtx.Unlock()
+
+	if info == nil {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(votingPeriodIndexDesc, prometheus.GaugeValue, float64(info.VotingPeriod.Index))
+	ch <- prometheus.MustNewConstMetric(votingPeriodPositionDesc, prometheus.GaugeValue, float64(info.Position))
+	ch <- prometheus.MustNewConstMetric(votingPeriodRemainingDesc, prometheus.GaugeValue, float64(info.Remaining))
+	ch <- prometheus.MustNewConstMetric(votingPeriodInfoDesc, prometheus.GaugeValue, 1, string(info.VotingPeriod.Kind))
+}
@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"sort"
+	"sync"
+)
+
+// cycleWindow keeps a sorted set of the most recent cycles
+type cycleWindow struct {
+	size   int
+	mtx    sync.Mutex
+	cycles []int
+}
+
+// add registers the cycle and returns the ones dropped out of the window. Returns false if the cycle itself is out of the window.
+func (w *cycleWindow) add(cycle int) (evicted []int, ok bool) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	i := sort.SearchInts(w.cycles, cycle)
+	if i < len(w.cycles) && w.cycles[i] == cycle {
+		return nil, true
+	}
+	if len(w.cycles) >= w.size && i == 0 {
+		return nil, false
+	}
+	w.cycles = append(w.cycles, 0)
+	copy(w.cycles[i+1:], w.cycles[i:])
+	w.cycles[i] = cycle
+
+	for len(w.cycles) > w.size {
+		evicted = append(evicted, w.cycles[0])
+		w.cycles = w.cycles[1:]
+	}
+	return evicted, true
+}
+
+// levelGuard remembers the last counted head block level. Heads replayed on reconnect or replaced by a higher round are counted once.
+type levelGuard struct {
+	mtx   sync.Mutex
+	level int
+}
+
+// next registers the level and returns false if it was counted already
+func (g *levelGuard) next(level int) bool {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if level <= g.level {
+		return false
+	}
+	g.level = level
+	return true
+}
@@ -0,0 +1,32 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCycleWindow(t *testing.T) {
+	tests := []struct {
+		cycle   int
+		evicted []int
+		ok      bool
+		cycles  []int
+	}{
+		{cycle: 10, ok: true, cycles: []int{10}},
+		{cycle: 10, ok: true, cycles: []int{10}},
+		{cycle: 12, ok: true, cycles: []int{10, 12}},
+		{cycle: 11, evicted: []int{10}, ok: true, cycles: []int{11, 12}},
+		// Cycles older than the window are rejected once it's full
+		{cycle: 9, ok: false, cycles: []int{11, 12}},
+		{cycle: 14, evicted: []int{11}, ok: true, cycles: []int{12, 14}},
+	}
+
+	w := cycleWindow{size: 2}
+	for _, test := range tests {
+		evicted, ok := w.add(test.cycle)
+		require.Equal(t, test.evicted, evicted, test.cycle)
+		require.Equal(t, test.ok, ok, test.cycle)
+		require.Equal(t, test.cycles, w.cycles, test.cycle)
+	}
+}
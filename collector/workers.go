@@ -0,0 +1,97 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	workerRunningDesc = prometheus.NewDesc(
+		"tezos_node_worker_running",
+		"Whether the node worker is in the running phase.",
+		[]string{"worker"},
+		nil)
+
+	workerPendingDesc = prometheus.NewDesc(
+		"tezos_node_worker_pending_requests",
+		"The number of requests queued by the node worker.",
+		[]string{"worker"},
+		nil)
+
+	workerCurrentRequestDesc = prometheus.NewDesc(
+		"tezos_node_worker_current_request_seconds",
+		"Time the node worker has been handling its current request for. Steadily growing values indicate a stuck worker.",
+		[]string{"worker"},
+		nil)
+)
+
+// WorkersCollector reports the state and request queues of the block validator and the chain's validator and prevalidator workers
+type WorkersCollector struct {
+	service *tezos.Service
+	timeout time.Duration
+	chainID string
+}
+
+// NewWorkersCollector returns a new WorkersCollector.
+func NewWorkersCollector(service *tezos.Service, timeout time.Duration, chainID string) *WorkersCollector {
+	return &WorkersCollector{
+		service: service,
+		timeout: timeout,
+		chainID: chainID,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *WorkersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- workerRunningDesc
+	ch <- workerPendingDesc
+	ch <- workerCurrentRequestDesc
+}
+
+// Collect implements prometheus.Collector and is called by the Prometheus registry when collecting metrics.
+func (c *WorkersCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	now := time.Now()
+	for _, w := range []struct {
+		name string
+		path string
+		get  func(ctx context.Context) (*tezos.WorkerState, error)
+	}{
+		{"block_validator", "/workers/block_validator", c.service.GetBlockValidator},
+		{"chain_validator", "/workers/chain_validators/" + c.chainID, func(ctx context.Context) (*tezos.WorkerState, error) {
+			return c.service.GetChainValidator(ctx, c.chainID)
+		}},
+		{"prevalidator", "/workers/prevalidators/" + c.chainID, func(ctx context.Context) (*tezos.WorkerState, error) {
+			return c.service.GetPrevalidator(ctx, c.chainID)
+		}},
+	} {
+		state, err := w.get(ctx)
+		var val float64
+		if err != nil {
+			log.WithError(err).WithField("worker", w.name).Error("error getting worker state")
+			val = 1
+		} else {
+			var running float64
+			if state.Status.Phase == tezos.WorkerRunning {
+				running = 1
+			}
+			ch <- prometheus.MustNewConstMetric(workerRunningDesc, prometheus.GaugeValue, running, w.name)
+			ch <- prometheus.MustNewConstMetric(workerPendingDesc, prometheus.GaugeValue, float64(len(state.PendingRequests)), w.name)
+
+			if r := state.CurrentRequest; r != nil {
+				start := r.Pushed
+				if r.Treated != nil {
+					start = *r.Treated
+				}
+				ch <- prometheus.MustNewConstMetric(workerCurrentRequestDesc, prometheus.GaugeValue, now.Sub(start).Seconds(), w.name)
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(rpcFailedDesc, prometheus.GaugeValue, val, w.path)
+	}
+}
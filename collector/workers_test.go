@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkersCollector(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	now := time.Now().UTC()
+	pushed := now.Add(-2 * time.Minute).Format(time.RFC3339)
+	treated := now.Add(-time.Minute).Format(time.RFC3339)
+	srv.Handle("/workers/block_validator", &tezostest.Response{Body: []byte(`{
+		"status": {"phase": "running", "since": "2024-07-22T09:00:00Z"},
+		"pending_requests": [
+			{"pushed": "` + pushed + `", "request": {"block": "BKq199p1Hm1phfJ4DhuRjB6yBSJnDNG8sgMSnja9pXR96T2Hyy1"}},
+			{"pushed": "` + pushed + `", "request": {"block": "BLeXtGqVoRhgRKTQmr3Kc17UAZGXuTKjDcjrAfCB7rYtK8tx5C8"}}
+		],
+		"current_request": {"pushed": "` + pushed + `", "treated": "` + treated + `", "request": {"block": "BMZiJk9qPqQbrmVVn4FwrwJc2KxKCj2PZjZrGQnnjc3RyE1Hw8w"}}
+	}`)})
+	srv.Handle("/workers/chain_validators/main", &tezostest.Response{Body: []byte(`{
+		"status": {"phase": "launching", "since": "2024-07-22T09:00:00Z"},
+		"pending_requests": []
+	}`)})
+
+	c := withRPCFailed{NewWorkersCollector(newTestService(t, srv), time.Second, "main")}
+
+	requireMetrics(t, c, `
+# HELP tezos_node_worker_running Whether the node worker is in the running phase.
+# TYPE tezos_node_worker_running gauge
+tezos_node_worker_running{worker="block_validator"} 1
+tezos_node_worker_running{worker="chain_validator"} 0
+# HELP tezos_node_worker_pending_requests The number of requests queued by the node worker.
+# TYPE tezos_node_worker_pending_requests gauge
+tezos_node_worker_pending_requests{worker="block_validator"} 2
+tezos_node_worker_pending_requests{worker="chain_validator"} 0
+# HELP tezos_rpc_failed A gauge that is set to 1 when a metrics collection RPC failed during the current scrape, 0 otherwise.
+# TYPE tezos_rpc_failed gauge
+tezos_rpc_failed{rpc="/workers/block_validator"} 0
+tezos_rpc_failed{rpc="/workers/chain_validators/main"} 0
+tezos_rpc_failed{rpc="/workers/prevalidators/main"} 1
+`, "tezos_node_worker_running", "tezos_node_worker_pending_requests", "tezos_rpc_failed")
+
+	// The current request time is counted from the moment it was treated
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(c)
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	var current []float64
+	for _, f := range families {
+		if f.GetName() == "tezos_node_worker_current_request_seconds" {
+			for _, m := range f.GetMetric() {
+				require.Equal(t, "block_validator", m.GetLabel()[0].GetValue())
+				current = append(current, m.GetGauge().GetValue())
+			}
+		}
+	}
+	require.Len(t, current, 1)
+	require.InDelta(t, 60, current[0], 5)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"strings"
+)
+
+// configInfoFlags are the flags reported by tezos_exporter_config_info. Flags carrying credentials, addresses or URLs are left out.
+var configInfoFlags = []string{
+	"alternate-blocks",
+	"block-metadata",
+	"chain-id",
+	"churn-metrics",
+	"cycle-retention",
+	"detect-unknown-fields",
+	"disable-health-endpoint",
+	"head-source",
+	"invalid-block-info",
+	"light-network",
+	"mempool-pools",
+	"mempool-single-stream",
+	"network-cache-ttl",
+	"ok-conditions",
+	"ok-max-head-age",
+	"ok-min-peers",
+	"per-peer-metrics",
+	"preset",
+	"resolve-point-hostnames",
+	"rpc-cache-ttl",
+	"rpc-compression",
+	"rpc-max-attempts",
+	"rpc-metrics",
+	"rpc-timeout",
+	"slow-rpc-threshold",
+	"stream-transport",
+	"strict-decode",
+	"test-chain",
+	"trusted-point-metrics",
+	"worker-metrics",
+}
+
+// configSettings returns the effective values of configInfoFlags, presets included, keyed by the flag names with underscores
+func configSettings() map[string]string {
+	settings := make(map[string]string, len(configInfoFlags))
+	for _, name := range configInfoFlags {
+		if f := flag.Lookup(name); f != nil {
+			settings[strings.ReplaceAll(name, "-", "_")] = f.Value.String()
+		}
+	}
+	return settings
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+)
+
+// DumpsHandler serves retained RPC dumps to clients presenting the bearer token
+type DumpsHandler struct {
+	dumps *tezos.DumpBuffer
+	token string
+}
+
+func (h *DumpsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !authorized(w, r, h.token) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.dumps.Dumps())
+}
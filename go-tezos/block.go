@@ -34,6 +34,12 @@ type BlockInfo struct {
 	ProtocolData   string     `json:"protocol_data" yaml:"protocol_data"`
 }
 
+// ValidBlock is a block header reported by the valid blocks monitor
+type ValidBlock struct {
+	ChainID   string `json:"chain_id" yaml:"chain_id"`
+	BlockInfo `yaml:",inline"`
+}
+
 // RawBlockHeader is a part of the Tezos block data
 type RawBlockHeader struct {
 	Level            int        `json:"level" yaml:"level"`
@@ -50,6 +56,14 @@ type RawBlockHeader struct {
 	Signature        string     `json:"signature" yaml:"signature"`
 }
 
+// BlockHeader holds the block header returned by the header RPC
+type BlockHeader struct {
+	Protocol       string `json:"protocol" yaml:"protocol"`
+	ChainID        string `json:"chain_id" yaml:"chain_id"`
+	Hash           string `json:"hash" yaml:"hash"`
+	RawBlockHeader `yaml:",inline"`
+}
+
 // TestChainStatus is a variable structure depending on the Status field
 type TestChainStatus interface {
 	TestChainStatus() string
@@ -114,13 +128,23 @@ type BlockHeaderMetadata struct {
 	MaxOperationListLength []*MaxOperationListLength `json:"max_operation_list_length" yaml:"max_operation_list_length"`
 	Baker                  string                    `json:"baker" yaml:"baker"`
 	Level                  BlockHeaderMetadataLevel  `json:"level" yaml:"level"`
+	LevelInfo              *BlockHeaderMetadataLevel `json:"level_info" yaml:"level_info"`
 	VotingPeriodKind       string                    `json:"voting_period_kind" yaml:"voting_period_kind"`
+	VotingPeriodInfo       *VotingPeriodInfo         `json:"voting_period_info" yaml:"voting_period_info"`
 	NonceHash              string                    `json:"nonce_hash" yaml:"nonce_hash"`
 	ConsumedGas            *BigInt                   `json:"consumed_gas" yaml:"consumed_gas"`
 	Deactivated            []string                  `json:"deactivated" yaml:"deactivated"`
 	BalanceUpdates         BalanceUpdates            `json:"balance_updates" yaml:"balance_updates"`
 }
 
+// CurrentLevel returns the level info which is reported in level_info by newer protocols and in level by older ones
+func (bhm *BlockHeaderMetadata) CurrentLevel() *BlockHeaderMetadataLevel {
+	if bhm.LevelInfo != nil {
+		return bhm.LevelInfo
+	}
+	return &bhm.Level
+}
+
 func unmarshalTestChainStatus(data []byte) (TestChainStatus, error) {
 	var tmp GenericTestChainStatus
 	if err := json.Unmarshal(data, &tmp); err != nil {
@@ -163,6 +187,11 @@ func (bhm *BlockHeaderMetadata) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if len(tmp.TestChainStatus) == 0 {
+		// Removed from the metadata since Granada
+		return nil
+	}
+
 	tcs, err := unmarshalTestChainStatus(tmp.TestChainStatus)
 	if err != nil {
 		return err
@@ -182,3 +211,8 @@ type Block struct {
 	Metadata   BlockHeaderMetadata `json:"metadata" yaml:"metadata"`
 	Operations [][]*Operation      `json:"operations" yaml:"operations"`
 }
+
+// HasMetadata returns false if the block was requested without metadata
+func (b *Block) HasMetadata() bool {
+	return b.Metadata.Protocol != ""
+}
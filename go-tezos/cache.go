@@ -0,0 +1,107 @@
+package tezos
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+type cachedResponse struct {
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// ResponseCache keeps successful responses of GET requests to the matching paths for a limited time
+// so they are shared between scrapes. Streams are never cached.
+type ResponseCache struct {
+	ttl      time.Duration
+	patterns []string
+
+	mtx     sync.Mutex
+	entries map[string]*cachedResponse
+}
+
+// NewResponseCache returns a new ResponseCache. A pattern matches the path and its subpaths using path.Match rules,
+// e.g. /chains/*/blocks/*/context/delegates matches every delegate RPC of every block.
+func NewResponseCache(ttl time.Duration, patterns []string) *ResponseCache {
+	return &ResponseCache{
+		ttl:      ttl,
+		patterns: patterns,
+		entries:  make(map[string]*cachedResponse),
+	}
+}
+
+func (c *ResponseCache) matches(p string) bool {
+	segments := strings.Split(p, "/")
+	for _, pattern := range c.patterns {
+		n := strings.Count(pattern, "/") + 1
+		if n > len(segments) {
+			continue
+		}
+		if ok, _ := path.Match(pattern, strings.Join(segments[:n], "/")); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Purge drops all cached responses
+func (c *ResponseCache) Purge() {
+	if c == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.entries = make(map[string]*cachedResponse)
+}
+
+func (c *ResponseCache) get(key string) *http.Response {
+	c.mtx.Lock()
+	e, ok := c.entries[key]
+	c.mtx.Unlock()
+
+	if !ok || time.Now().After(e.expires) {
+		return nil
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+	}
+}
+
+// put reads and stores the response body replacing it with a copy
+func (c *ResponseCache) put(key string, resp *http.Response) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	now := time.Now()
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for k, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = &cachedResponse{
+		header:  resp.Header.Clone(),
+		body:    body,
+		expires: now.Add(c.ttl),
+	}
+	return nil
+}
@@ -8,9 +8,13 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httputil"
 	"net/url"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -58,9 +62,22 @@ func (c *RPCClient) NewRequest(ctx context.Context, method, urlStr string, body
 	}
 	req.Header.Add("User-Agent", c.UserAgent)
 
+	if c.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.BearerToken)
+	} else if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
 	return req, nil
 }
 
+var authorizationRegexp = regexp.MustCompile(`(?m)^(Authorization: ).*$`)
+
+// redactAuthorization hides the credentials in the request dump
+func redactAuthorization(dump []byte) []byte {
+	return authorizationRegexp.ReplaceAll(dump, []byte("${1}[REDACTED]\r"))
+}
+
 // RPCClient manages communication with a Tezos RPC server.
 type RPCClient struct {
 	// Logger
@@ -71,14 +88,60 @@ type RPCClient struct {
 	BaseURL *url.URL
 	// User agent name for client.
 	UserAgent string
+	// Optional credentials sent with every request. The bearer token takes precedence over the basic auth.
+	Username    string
+	Password    string
+	BearerToken string
+	// Optional buffer retaining the last request/response dumps regardless of the log level.
+	Dumps *DumpBuffer
+	// Fail on response fields not present in the target types.
+	StrictDecode bool
+	// Monitor stream transport. If nil it's chosen by the response content type.
+	Stream StreamTransport
+	// Optional handler called in the lenient mode for the first unknown field of the response.
+	// The response is decoded twice if set. Streams and types with custom unmarshallers aren't checked.
+	UnknownFieldHandler func(typ, field string)
+	// Optional retry policy of idempotent requests. Nil disables retries.
+	Retry *RetryPolicy
+	// Optional handler called before every retry with the failed attempt number and its error. The error is nil for 5xx responses.
+	RetryHandler func(req *http.Request, attempt int, err error)
+	// Optional cache of the responses of expensive RPCs
+	Cache *ResponseCache
+	// Optional handler called for every request matching the cache
+	CacheHandler func(req *http.Request, hit bool)
+	// Optional handler called after every node state mutating request of the Service (bans, trusts, connections and
+	// greylist clearing) with its action, target peer or point and error. The actions are logged regardless.
+	AdminActionHandler func(action, target string, err error)
 }
 
-// NewRPCClient returns a new Tezos RPC client.
+// RetryPolicy makes the client retry GET and HEAD requests failed with a connection error or a 5xx status.
+// The delay starts at Backoff and doubles after every attempt. Retries stop once the request context is done.
+type RetryPolicy struct {
+	// Maximum number of attempts including the first one
+	MaxAttempts int
+	// Delay before the first retry
+	Backoff time.Duration
+	// Delay limit. Zero means no limit.
+	MaxBackoff time.Duration
+}
+
+// NewRPCClient returns a new Tezos RPC client. unix:///path/to/socket URLs make the client talk to the node over
+// the Unix domain socket.
 func NewRPCClient(baseURL string) (*RPCClient, error) {
 	u, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, err
 	}
+	if u.Scheme == "unix" {
+		if u.Path == "" {
+			return nil, fmt.Errorf("tezos: missing socket path in %s", baseURL)
+		}
+		base := unixBaseURL
+		return &RPCClient{
+			BaseURL:   &base,
+			Transport: NewUnixTransport(u.Path),
+		}, nil
+	}
 	return &RPCClient{
 		BaseURL: u,
 	}, nil
@@ -98,7 +161,14 @@ func (c *RPCClient) handleNormalResponse(ctx context.Context, resp *http.Respons
 	if typ.Kind() == reflect.Chan {
 		// Handle channel
 		dumpResponse(c.log(), log.DebugLevel, resp, false)
-		dec := json.NewDecoder(resp.Body)
+		stream := c.Stream
+		if stream == nil {
+			stream = autoStream(resp)
+		}
+		dec := stream.NewDecoder(resp.Body)
+		if s, ok := dec.(interface{ DisallowUnknownFields() }); ok && c.StrictDecode {
+			s.DisallowUnknownFields()
+		}
 
 		cases := []reflect.SelectCase{
 			{
@@ -135,9 +205,24 @@ func (c *RPCClient) handleNormalResponse(ctx context.Context, resp *http.Respons
 
 	// Handle single object
 	dumpResponse(c.log(), log.DebugLevel, resp, true)
-	dec := json.NewDecoder(resp.Body)
-	if err := dec.Decode(&v); err != nil {
-		return err
+
+	if !c.StrictDecode && c.UnknownFieldHandler != nil {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(body, &v); err != nil {
+			return err
+		}
+		c.checkUnknownFields(body, typ)
+	} else {
+		dec := json.NewDecoder(resp.Body)
+		if c.StrictDecode {
+			dec.DisallowUnknownFields()
+		}
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
 	}
 
 	spewDump(c.log(), log.TraceLevel, v)
@@ -145,6 +230,33 @@ func (c *RPCClient) handleNormalResponse(ctx context.Context, resp *http.Respons
 	return nil
 }
 
+const unknownFieldErrPrefix = "json: unknown field "
+
+// checkUnknownFields decodes the data into a new value of the type once again reporting unknown fields
+func (c *RPCClient) checkUnknownFields(data []byte, typ reflect.Type) {
+	if typ.Kind() != reflect.Ptr {
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(reflect.New(typ.Elem()).Interface())
+	if err == nil || !strings.HasPrefix(err.Error(), unknownFieldErrPrefix) {
+		return
+	}
+
+	field, e := strconv.Unquote(strings.TrimPrefix(err.Error(), unknownFieldErrPrefix))
+	if e != nil {
+		field = strings.TrimPrefix(err.Error(), unknownFieldErrPrefix)
+	}
+
+	// Use the base type name, e.g. NetworkPeer for *[]*NetworkPeer
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+	c.UnknownFieldHandler(typ.Name(), field)
+}
+
 func (c *RPCClient) transport() http.RoundTripper {
 	if c.Transport != nil {
 		return c.Transport
@@ -152,18 +264,122 @@ func (c *RPCClient) transport() http.RoundTripper {
 	return http.DefaultTransport
 }
 
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode/100 == 5
+}
+
+// send sends the request retrying it according to the policy
+func (c *RPCClient) send(req *http.Request) (*http.Response, error) {
+	client := &http.Client{
+		Transport: c.transport(),
+	}
+
+	policy := c.Retry
+	if policy == nil || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return client.Do(req)
+	}
+
+	backoff := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(req)
+		if attempt >= policy.MaxAttempts || !isRetryable(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		fields := log.Fields{"url": req.URL.String(), "attempt": attempt}
+		if err != nil {
+			c.log().WithError(err).WithFields(fields).Debug("retrying RPC request")
+		} else {
+			c.log().WithField("status", resp.StatusCode).WithFields(fields).Debug("retrying RPC request")
+			// Drain the body to reuse the connection
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if c.RetryHandler != nil {
+			c.RetryHandler(req, attempt, err)
+		}
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-t.C:
+		case <-req.Context().Done():
+			t.Stop()
+			return nil, req.Context().Err()
+		}
+
+		backoff *= 2
+		if policy.MaxBackoff != 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// cachedSend returns the cached response if there is one or sends the request caching a successful response
+func (c *RPCClient) cachedSend(req *http.Request, isStream bool) (*http.Response, error) {
+	if c.Cache == nil || isStream || req.Method != http.MethodGet || !c.Cache.matches(req.URL.Path) {
+		return c.send(req)
+	}
+
+	key := req.URL.String()
+	if resp := c.Cache.get(key); resp != nil {
+		if c.CacheHandler != nil {
+			c.CacheHandler(req, true)
+		}
+		resp.Request = req
+		return resp, nil
+	}
+	if c.CacheHandler != nil {
+		c.CacheHandler(req, false)
+	}
+
+	resp, err := c.send(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	if err := c.Cache.put(key, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // Do retrieves values from the API and marshals them into the provided interface.
 func (c *RPCClient) Do(req *http.Request, v interface{}) (err error) {
+	isStream := v != nil && reflect.TypeOf(v).Kind() == reflect.Chan
+	if isStream && c.Stream != nil {
+		c.Stream.PrepareRequest(req)
+	}
+
 	dumpRequest(c.log(), log.DebugLevel, req)
 
-	client := &http.Client{
-		Transport: c.transport(),
+	var dump *Dump
+	if c.Dumps != nil {
+		dump = &Dump{Time: time.Now()}
+		if buf, err := httputil.DumpRequestOut(req, true); err == nil {
+			dump.Request = string(redactAuthorization(buf))
+		}
+		defer func() {
+			if err != nil {
+				dump.Error = err.Error()
+			}
+			c.Dumps.Add(dump)
+		}()
 	}
-	resp, err := client.Do(req)
+
+	resp, err := c.cachedSend(req, isStream)
 	if err != nil {
 		return err
 	}
 
+	if dump != nil {
+		// Don't consume streams
+		if buf, err := httputil.DumpResponse(resp, !isStream); err == nil {
+			dump.Response = string(buf)
+		}
+	}
+
 	defer func() {
 		if rerr := resp.Body.Close(); err == nil {
 			err = rerr
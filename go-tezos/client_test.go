@@ -0,0 +1,354 @@
+package tezos
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_sent":"1","total_recv":"2","current_inflow":3,"current_outflow":4,"new_field":5}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	s := &Service{Client: c}
+
+	var typ, field string
+	c.UnknownFieldHandler = func(t, f string) {
+		typ, field = t, f
+	}
+
+	stats, err := s.GetNetworkStats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, &NetworkStats{TotalBytesSent: 1, TotalBytesRecv: 2, CurrentInflow: 3, CurrentOutflow: 4}, stats)
+	require.Equal(t, "NetworkStats", typ)
+	require.Equal(t, "new_field", field)
+
+	c.StrictDecode = true
+	_, err = s.GetNetworkStats(context.Background())
+	require.EqualError(t, err, `json: unknown field "new_field"`)
+}
+
+func TestRetry(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1)%3 != 0 || r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_sent":"1","total_recv":"2","current_inflow":3,"current_outflow":4}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	s := &Service{Client: c}
+
+	var attempts []int
+	c.Retry = &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+	c.RetryHandler = func(req *http.Request, attempt int, err error) {
+		attempts = append(attempts, attempt)
+	}
+
+	_, err = s.GetNetworkStats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, attempts)
+	require.Equal(t, int32(3), requests)
+
+	// Out of attempts
+	c.Retry.MaxAttempts = 2
+	_, err = s.GetNetworkStats(context.Background())
+	require.Error(t, err)
+	require.Equal(t, int32(5), requests)
+
+	// Non idempotent requests aren't retried
+	req, err := c.NewRequest(context.Background(), http.MethodPost, "/injection/operation", "")
+	require.NoError(t, err)
+	require.Error(t, c.Do(req, nil))
+	require.Equal(t, int32(6), requests)
+}
+
+func TestMetadataTooLarge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("metadata") == MetadataNever {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"protocol":"PtLimaPtLMwfNinJi9rCfDPWea8dFgTZ1MeJ9f1m2SRic6ayiwW","hash":"BLnoMeta"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Metadata too large"))
+	}))
+	defer srv.Close()
+
+	c, err := NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	s := &Service{Client: c}
+
+	_, err = s.GetBlock(context.Background(), "main", "head")
+	require.True(t, IsMetadataTooLarge(err))
+
+	block, err := s.GetBlockWithOptions(context.Background(), "main", "head", &BlockOptions{Metadata: MetadataNever})
+	require.NoError(t, err)
+	require.False(t, IsMetadataTooLarge(err))
+	require.Equal(t, "BLnoMeta", block.Hash)
+	require.False(t, block.HasMetadata())
+}
+
+func TestResponseCache(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/network/stat" {
+			w.Write([]byte(`{"total_sent":"1","total_recv":"2","current_inflow":3,"current_outflow":4}`))
+		} else {
+			w.Write([]byte(`"1000"`))
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	s := &Service{Client: c}
+
+	var hits, misses int
+	c.Cache = NewResponseCache(time.Minute, []string{"/chains/*/blocks/*/context/delegates"})
+	c.CacheHandler = func(req *http.Request, hit bool) {
+		if hit {
+			hits++
+		} else {
+			misses++
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		balance, err := s.GetDelegateBalance(context.Background(), "main", "head", "tz1a")
+		require.NoError(t, err)
+		require.Equal(t, int64(1000), balance.Int64())
+		_, err = s.GetNetworkStats(context.Background())
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(3), requests)
+	require.Equal(t, 1, hits)
+	require.Equal(t, 1, misses)
+
+	// Different URLs are cached separately
+	_, err = s.GetDelegateBalance(context.Background(), "main", "head", "tz1b")
+	require.NoError(t, err)
+	require.Equal(t, int32(4), requests)
+
+	c.Cache.Purge()
+	_, err = s.GetDelegateBalance(context.Background(), "main", "head", "tz1a")
+	require.NoError(t, err)
+	require.Equal(t, int32(5), requests)
+}
+
+func TestTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_sent":"1","total_recv":"2","current_inflow":3,"current_outflow":4}`))
+	}))
+	defer srv.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0600))
+
+	c, err := NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	s := &Service{Client: c}
+
+	_, err = s.GetNetworkStats(context.Background())
+	require.Error(t, err)
+
+	for _, opts := range []*TLSOptions{{CAFile: caFile}, {InsecureSkipVerify: true}} {
+		cfg, err := opts.Config()
+		require.NoError(t, err)
+		c.Transport = NewTransport(cfg)
+		_, err = s.GetNetworkStats(context.Background())
+		require.NoError(t, err)
+	}
+
+	_, err = (&TLSOptions{CertFile: caFile}).Config()
+	require.Error(t, err)
+}
+
+func TestAuth(t *testing.T) {
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_sent":"1","total_recv":"2","current_inflow":3,"current_outflow":4}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	c.Dumps = NewDumpBuffer(1)
+	s := &Service{Client: c}
+
+	c.Username, c.Password = "user", "secret"
+	_, err = s.GetNetworkStats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Basic dXNlcjpzZWNyZXQ=", auth)
+
+	c.BearerToken = "token"
+	_, err = s.GetNetworkStats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Bearer token", auth)
+
+	require.Contains(t, c.Dumps.Dumps()[0].Request, "Authorization: [REDACTED]\r\n")
+	require.NotContains(t, c.Dumps.Dumps()[0].Request, "token")
+}
+
+func TestUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "node.sock")
+	l, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	var path string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_sent":"1","total_recv":"2","current_inflow":3,"current_outflow":4}`))
+	}))
+	srv.Listener = l
+	srv.Start()
+	defer srv.Close()
+
+	c, err := NewRPCClient("unix://" + socket)
+	require.NoError(t, err)
+	s := &Service{Client: c}
+
+	stats, err := s.GetNetworkStats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats.TotalBytesRecv)
+	require.Equal(t, "/network/stat", path)
+
+	_, err = NewRPCClient("unix://")
+	require.Error(t, err)
+}
+
+func TestProxy(t *testing.T) {
+	var target string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_sent":"1","total_recv":"2","current_inflow":3,"current_outflow":4}`))
+	}))
+	defer proxy.Close()
+
+	c, err := NewRPCClient("http://node.invalid:8732")
+	require.NoError(t, err)
+	fn, err := ProxyFunc(proxy.URL)
+	require.NoError(t, err)
+	tr := NewTransport(nil)
+	tr.Proxy = fn
+	c.Transport = tr
+	s := &Service{Client: c}
+
+	_, err = s.GetNetworkStats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "http://node.invalid:8732/network/stat", target)
+
+	_, err = ProxyFunc("ftp://proxy:21")
+	require.Error(t, err)
+}
+
+func TestAdminActionHandler(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/network/points/80.214.69.170:9732/trust" {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	c, err := NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	var actions []string
+	c.AdminActionHandler = func(action, target string, err error) {
+		actions = append(actions, fmt.Sprintf("%s %s %t", action, target, err != nil))
+	}
+	s := &Service{Client: c}
+
+	require.NoError(t, s.BanNetworkPeer(context.Background(), "idtTZmNapGXAcfbnPoAcDz6J2xCHZZ"))
+	require.Error(t, s.TrustNetworkPoint(context.Background(), "80.214.69.170:9732"))
+	require.NoError(t, s.ClearNetworkGreylist(context.Background()))
+	_, err = s.GetNetworkPeerBanned(context.Background(), "idtTZmNapGXAcfbnPoAcDz6J2xCHZZ")
+	require.Error(t, err)
+
+	require.Equal(t, []string{
+		"ban_peer idtTZmNapGXAcfbnPoAcDz6J2xCHZZ false",
+		"trust_point 80.214.69.170:9732 true",
+		"clear_greylist  false",
+	}, actions)
+}
+
+func TestGzip(t *testing.T) {
+	release := make(chan struct{})
+	// Request headers are checked by the test goroutine
+	encodings := make(chan string, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings <- r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		defer zw.Close()
+
+		if r.URL.Path == "/network/stat" {
+			zw.Write([]byte(`{"total_sent":"1","total_recv":"2","current_inflow":3,"current_outflow":4}`))
+			return
+		}
+		// The first head must be decoded before the stream ends
+		zw.Write([]byte(`{"hash":"BKq199p1Hm1phfJ4DhuRjB6yBSJnDNG8sgMSnja9pXR96T2Hyy1","level":390397}` + "\n"))
+		zw.Flush()
+		w.(http.Flusher).Flush()
+		<-release
+	}))
+	defer srv.Close()
+	var once sync.Once
+	defer once.Do(func() { close(release) })
+
+	c, err := NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	s := &Service{Client: c}
+
+	stats, err := s.GetNetworkStats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats.TotalBytesRecv)
+
+	heads := make(chan *BlockInfo, 1)
+	done := make(chan error, 1)
+	go func() { done <- s.MonitorHeads(context.Background(), "main", heads) }()
+	select {
+	case head := <-heads:
+		require.Equal(t, 390397, head.Level)
+	case <-time.After(5 * time.Second):
+		t.Fatal("head not received")
+	}
+	once.Do(func() { close(release) })
+	require.NoError(t, <-done)
+
+	require.Equal(t, "gzip", <-encodings)
+	require.Equal(t, "gzip", <-encodings)
+}
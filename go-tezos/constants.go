@@ -0,0 +1,58 @@
+package tezos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrNoConstant is returned by Constants.Get for constants missing in the protocol
+var ErrNoConstant = errors.New("tezos: no such protocol constant")
+
+// Constants holds commonly used protocol constants. Fields missing in the current protocol are left zero.
+// All constants including the ones without a field are kept in Raw.
+type Constants struct {
+	PreservedCycles        int `json:"preserved_cycles" yaml:"preserved_cycles"`
+	BlocksPerCycle         int `json:"blocks_per_cycle" yaml:"blocks_per_cycle"`
+	EndorsersPerBlock      int `json:"endorsers_per_block" yaml:"endorsers_per_block"`
+	ConsensusCommitteeSize int `json:"consensus_committee_size" yaml:"consensus_committee_size"`
+	ConsensusThreshold     int `json:"consensus_threshold" yaml:"consensus_threshold"`
+
+	MinimalBlockDelay            int64 `json:"minimal_block_delay,string" yaml:"minimal_block_delay"`
+	HardGasLimitPerOperation     int64 `json:"hard_gas_limit_per_operation,string" yaml:"hard_gas_limit_per_operation"`
+	HardGasLimitPerBlock         int64 `json:"hard_gas_limit_per_block,string" yaml:"hard_gas_limit_per_block"`
+	HardStorageLimitPerOperation int64 `json:"hard_storage_limit_per_operation,string" yaml:"hard_storage_limit_per_operation"`
+
+	// Reward amounts before Oxford
+	BakingRewardFixedPortion *BigInt `json:"baking_reward_fixed_portion" yaml:"baking_reward_fixed_portion"`
+	BakingRewardBonusPerSlot *BigInt `json:"baking_reward_bonus_per_slot" yaml:"baking_reward_bonus_per_slot"`
+	EndorsingRewardPerSlot   *BigInt `json:"endorsing_reward_per_slot" yaml:"endorsing_reward_per_slot"`
+
+	Raw map[string]json.RawMessage `json:"-" yaml:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *Constants) UnmarshalJSON(data []byte) error {
+	type constants Constants
+	if err := json.Unmarshal(data, (*constants)(c)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &c.Raw)
+}
+
+// Get decodes the named constant into v. ErrNoConstant is returned if the protocol doesn't have it.
+func (c *Constants) Get(name string, v interface{}) error {
+	raw, ok := c.Raw[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoConstant, name)
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// MaxEndorsementPower returns the maximum endorsement power that can be included into a block
+func (c *Constants) MaxEndorsementPower() int {
+	if c.ConsensusCommitteeSize != 0 {
+		return c.ConsensusCommitteeSize
+	}
+	return c.EndorsersPerBlock
+}
@@ -0,0 +1,26 @@
+package tezos
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstantsGet(t *testing.T) {
+	buf, err := ioutil.ReadFile("fixtures/chains/constants.json")
+	require.NoError(t, err)
+	var c Constants
+	require.NoError(t, json.Unmarshal(buf, &c))
+
+	var depth int
+	require.NoError(t, c.Get("consensus_rights_delay", &depth))
+	require.Equal(t, 2, depth)
+
+	var limit BigInt
+	require.NoError(t, c.Get("smart_rollup_max_number_of_messages_per_level", &limit))
+	require.Equal(t, "1000000", limit.String())
+
+	require.ErrorIs(t, c.Get("endorsers_per_block", &depth), ErrNoConstant)
+}
@@ -0,0 +1,62 @@
+package tezos
+
+import (
+	"sync"
+	"time"
+)
+
+// Dump represents a recorded RPC request and response
+type Dump struct {
+	Time     time.Time `json:"time"`
+	Request  string    `json:"request"`
+	Response string    `json:"response,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// DumpBuffer is a ring buffer retaining the last N request/response dumps
+type DumpBuffer struct {
+	mtx  sync.Mutex
+	buf  []*Dump
+	pos  int
+	full bool
+}
+
+// NewDumpBuffer returns a new DumpBuffer of given size
+func NewDumpBuffer(size int) *DumpBuffer {
+	return &DumpBuffer{
+		buf: make([]*Dump, size),
+	}
+}
+
+// Add adds the dump to the buffer overwriting the oldest one if the buffer is full
+func (b *DumpBuffer) Add(d *Dump) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if len(b.buf) == 0 {
+		return
+	}
+
+	b.buf[b.pos] = d
+	b.pos++
+	if b.pos == len(b.buf) {
+		b.pos = 0
+		b.full = true
+	}
+}
+
+// Dumps returns retained dumps, oldest first
+func (b *DumpBuffer) Dumps() []*Dump {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if !b.full {
+		ret := make([]*Dump, b.pos)
+		copy(ret, b.buf[:b.pos])
+		return ret
+	}
+
+	ret := make([]*Dump, 0, len(b.buf))
+	ret = append(ret, b.buf[b.pos:]...)
+	return append(ret, b.buf[:b.pos]...)
+}
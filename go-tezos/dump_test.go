@@ -0,0 +1,25 @@
+package tezos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpBuffer(t *testing.T) {
+	b := NewDumpBuffer(3)
+	require.Empty(t, b.Dumps())
+
+	d := []*Dump{{Request: "0"}, {Request: "1"}, {Request: "2"}, {Request: "3"}, {Request: "4"}}
+
+	b.Add(d[0])
+	b.Add(d[1])
+	require.Equal(t, d[:2], b.Dumps())
+
+	b.Add(d[2])
+	require.Equal(t, d[:3], b.Dumps())
+
+	b.Add(d[3])
+	b.Add(d[4])
+	require.Equal(t, d[2:], b.Dumps())
+}
@@ -1,7 +1,9 @@
 package tezos
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -13,6 +15,8 @@ const (
 	ErrorKindTemporary = "temporary"
 	// ErrorKindBranch Tezos RPC error kind.
 	ErrorKindBranch = "branch"
+	// ErrorKindOutdated Tezos RPC error kind.
+	ErrorKindOutdated = "outdated"
 )
 
 // Error is a Tezos error as documented on http://tezos.gitlab.io/mainnet/api/errors.html.
@@ -161,6 +165,15 @@ func (e *plainError) Error() string {
 	return e.msg
 }
 
+// IsMetadataTooLarge returns true if the node refused to return the block because its metadata exceeds the node's size limit
+func IsMetadataTooLarge(err error) bool {
+	var e HTTPError
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.StatusCode()/100 == 5 && bytes.Contains(bytes.ToLower(e.Body()), []byte("too large"))
+}
+
 var (
 	_ Error    = &GenericError{}
 	_ Error    = Errors{}
@@ -0,0 +1,11 @@
+package tezos
+
+// ExpectedIssuance holds the reward amounts expected for the cycle since Oxford
+type ExpectedIssuance struct {
+	Cycle                    int     `json:"cycle" yaml:"cycle"`
+	BakingRewardFixedPortion *BigInt `json:"baking_reward_fixed_portion" yaml:"baking_reward_fixed_portion"`
+	BakingRewardBonusPerSlot *BigInt `json:"baking_reward_bonus_per_slot" yaml:"baking_reward_bonus_per_slot"`
+	AttestingRewardPerSlot   *BigInt `json:"attesting_reward_per_slot" yaml:"attesting_reward_per_slot"`
+	SeedNonceRevelationTip   *BigInt `json:"seed_nonce_revelation_tip" yaml:"seed_nonce_revelation_tip"`
+	VDFRevelationTip         *BigInt `json:"vdf_revelation_tip" yaml:"vdf_revelation_tip"`
+}
@@ -0,0 +1,74 @@
+package tezos
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Mempool pools accepted by the operations monitor. PoolApplied is the name of PoolValidated used by older nodes.
+const (
+	PoolValidated     = "validated"
+	PoolApplied       = "applied"
+	PoolRefused       = "refused"
+	PoolOutdated      = "outdated"
+	PoolBranchRefused = "branch_refused"
+	PoolBranchDelayed = "branch_delayed"
+)
+
+// mempoolPools lists the pools in the query parameter order
+var mempoolPools = []string{PoolValidated, PoolRefused, PoolOutdated, PoolBranchRefused, PoolBranchDelayed}
+
+// MempoolFilter selects the pools streamed by the mempool operations monitor
+type MempoolFilter struct {
+	Pools []string
+	// Explicit sets every pool parameter to true or false (?validated=true&refused=false...) which is required by newer nodes
+	// as they stream validated operations unless told otherwise. Otherwise the pools are given as valueless flags (?applied&refused)
+	// understood by older nodes.
+	Explicit bool
+}
+
+// ParseMempoolFilter returns a filter of the comma separated pool list
+func ParseMempoolFilter(pools string, explicit bool) *MempoolFilter {
+	return &MempoolFilter{
+		Pools:    strings.Split(pools, ","),
+		Explicit: explicit,
+	}
+}
+
+// Query returns the query string of the filter
+func (f *MempoolFilter) Query() string {
+	if !f.Explicit {
+		return strings.Join(f.Pools, "&")
+	}
+
+	selected := make(map[string]bool, len(f.Pools))
+	for _, p := range f.Pools {
+		if p == PoolApplied {
+			p = PoolValidated
+		}
+		selected[p] = true
+	}
+
+	var parts []string
+	for _, p := range mempoolPools {
+		parts = append(parts, p+"="+strconv.FormatBool(selected[p]))
+	}
+	return strings.Join(parts, "&")
+}
+
+// MempoolPool returns the pool of an operation streamed by the mempool monitor judging by its errors.
+// Validated operations come without errors.
+func MempoolPool(errs Errors) string {
+	switch errs.ErrorKind() {
+	case "":
+		return PoolValidated
+	case ErrorKindTemporary:
+		return PoolBranchDelayed
+	case ErrorKindBranch:
+		return PoolBranchRefused
+	case ErrorKindOutdated:
+		return PoolOutdated
+	default:
+		return PoolRefused
+	}
+}
@@ -0,0 +1,52 @@
+package tezos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMempoolFilterQuery(t *testing.T) {
+	tests := []struct {
+		filter *MempoolFilter
+		query  string
+	}{
+		{
+			filter: ParseMempoolFilter("applied", false),
+			query:  "applied",
+		},
+		{
+			filter: ParseMempoolFilter("applied,refused,branch_delayed", false),
+			query:  "applied&refused&branch_delayed",
+		},
+		{
+			filter: ParseMempoolFilter("refused", true),
+			query:  "validated=false&refused=true&outdated=false&branch_refused=false&branch_delayed=false",
+		},
+		{
+			filter: ParseMempoolFilter("applied,outdated,branch_refused", true),
+			query:  "validated=true&refused=false&outdated=true&branch_refused=true&branch_delayed=false",
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.query, test.filter.Query())
+	}
+}
+
+func TestMempoolPool(t *testing.T) {
+	tests := []struct {
+		errs Errors
+		pool string
+	}{
+		{errs: nil, pool: PoolValidated},
+		{errs: Errors{&GenericError{Kind: ErrorKindPermanent}}, pool: PoolRefused},
+		{errs: Errors{&GenericError{Kind: ErrorKindTemporary}}, pool: PoolBranchDelayed},
+		{errs: Errors{&GenericError{Kind: ErrorKindBranch}}, pool: PoolBranchRefused},
+		{errs: Errors{&GenericError{Kind: ErrorKindOutdated}}, pool: PoolOutdated},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.pool, MempoolPool(test.errs))
+	}
+}
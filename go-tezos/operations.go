@@ -20,6 +20,16 @@ type OperationWithFee interface {
 	OperationFee() *big.Int
 }
 
+// DenunciationOperation is implemented by double signing evidence operations
+type DenunciationOperation interface {
+	AccusedDelegate() string
+}
+
+// OperationWithGasLimit is implemented by operations with gas limit
+type OperationWithGasLimit interface {
+	OperationGasLimit() *big.Int
+}
+
 // GenericOperationElem is a most generic element type
 type GenericOperationElem struct {
 	Kind string `json:"kind" yaml:"kind"`
@@ -51,10 +61,12 @@ opLoop:
 		}
 
 		switch tmp.Kind {
-		case "endorsement":
+		case "endorsement", "attestation", "attestation_with_dal":
 			(*e)[i] = &EndorsementOperationElem{}
 		case "endorsement_with_slot":
 			(*e)[i] = &EndorsementWithSlotOperationElem{}
+		case "preendorsement", "preattestation":
+			(*e)[i] = &PreendorsementOperationElem{}
 		case "transaction":
 			(*e)[i] = &TransactionOperationElem{}
 		case "ballot":
@@ -63,7 +75,7 @@ opLoop:
 			(*e)[i] = &ProposalOperationElem{}
 		case "seed_nonce_revelation":
 			(*e)[i] = &SeedNonceRevelationOperationElem{}
-		case "double_endorsement_evidence":
+		case "double_endorsement_evidence", "double_preendorsement_evidence", "double_attestation_evidence", "double_preattestation_evidence":
 			(*e)[i] = &DoubleEndorsementEvidenceOperationElem{}
 		case "double_baking_evidence":
 			(*e)[i] = &DoubleBakingEvidenceOperationElem{}
@@ -75,6 +87,9 @@ opLoop:
 			(*e)[i] = &OriginationOperationElem{}
 		case "delegation":
 			(*e)[i] = &DelegationOperationElem{}
+		case "smart_rollup_originate", "smart_rollup_add_messages", "smart_rollup_cement", "smart_rollup_publish",
+			"smart_rollup_refute", "smart_rollup_timeout", "smart_rollup_execute_outbox_message", "smart_rollup_recover_bond":
+			(*e)[i] = &SmartRollupOperationElem{}
 		default:
 			(*e)[i] = &tmp
 			continue opLoop
@@ -88,7 +103,7 @@ opLoop:
 	return nil
 }
 
-// EndorsementOperationElem represents an endorsement operation
+// EndorsementOperationElem represents an endorsement operation (called attestation since Oxford)
 type EndorsementOperationElem struct {
 	GenericOperationElem `yaml:",inline"`
 	Level                int                          `json:"level" yaml:"level"`
@@ -98,7 +113,17 @@ type EndorsementOperationElem struct {
 // EndorsementOperationElem represents an endorsement_with_slot operation that was introduced in Edo
 type EndorsementWithSlotOperationElem struct {
 	GenericOperationElem `yaml:",inline"`
-	Level                int `json:"level" yaml:"level"`
+	Level                int                          `json:"level" yaml:"level"`
+	Metadata             EndorsementOperationMetadata `json:"metadata" yaml:"metadata"`
+}
+
+// PreendorsementOperationElem represents a Tenderbake preendorsement operation (called preattestation since Oxford).
+// Blocks include them as the proof of the locked round.
+type PreendorsementOperationElem struct {
+	GenericOperationElem `yaml:",inline"`
+	Level                int                          `json:"level" yaml:"level"`
+	Round                int                          `json:"round" yaml:"round"`
+	Metadata             EndorsementOperationMetadata `json:"metadata" yaml:"metadata"`
 }
 
 // BalanceUpdates implements BalanceUpdateOperation
@@ -106,11 +131,64 @@ func (el *EndorsementOperationElem) BalanceUpdates() BalanceUpdates {
 	return el.Metadata.BalanceUpdates
 }
 
+// Power returns the endorsement power of the operation
+func (el *EndorsementOperationElem) Power() int {
+	return el.Metadata.Power()
+}
+
+// Power returns the endorsement power of the operation
+func (el *EndorsementWithSlotOperationElem) Power() int {
+	return el.Metadata.Power()
+}
+
+// PreendorsementPower returns the preendorsement power of the operation
+func (el *PreendorsementOperationElem) PreendorsementPower() int {
+	return el.Metadata.Power()
+}
+
 // EndorsementOperationMetadata represents an endorsement operation metadata
 type EndorsementOperationMetadata struct {
-	BalanceUpdates BalanceUpdates `json:"balance_updates" yaml:"balance_updates"`
-	Delegate       string         `json:"delegate" yaml:"delegate"`
-	Slots          []int          `json:"slots" yaml:"slots,flow"`
+	BalanceUpdates      BalanceUpdates `json:"balance_updates" yaml:"balance_updates"`
+	Delegate            string         `json:"delegate" yaml:"delegate"`
+	Slots               []int          `json:"slots" yaml:"slots,flow"`
+	EndorsementPower    int            `json:"endorsement_power" yaml:"endorsement_power"`
+	PreendorsementPower int            `json:"preendorsement_power" yaml:"preendorsement_power"`
+	ConsensusPower      ConsensusPower `json:"consensus_power" yaml:"consensus_power"`
+}
+
+// Power returns the endorsement power: consensus_power since Oxford, endorsement_power (preendorsement_power) since Ithaca
+// and the number of slots before
+func (m *EndorsementOperationMetadata) Power() int {
+	switch {
+	case m.ConsensusPower != 0:
+		return int(m.ConsensusPower)
+	case m.EndorsementPower != 0:
+		return m.EndorsementPower
+	case m.PreendorsementPower != 0:
+		return m.PreendorsementPower
+	}
+	return len(m.Slots)
+}
+
+// ConsensusPower is a consensus power reported either as a number or as an object with the slots count in later protocols
+type ConsensusPower int
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *ConsensusPower) UnmarshalJSON(data []byte) error {
+	var v int
+	if err := json.Unmarshal(data, &v); err == nil {
+		*c = ConsensusPower(v)
+		return nil
+	}
+
+	var tmp struct {
+		Slots int `json:"slots"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*c = ConsensusPower(tmp.Slots)
+	return nil
 }
 
 // TransactionOperationElem represents a transaction operation
@@ -140,6 +218,14 @@ func (el *TransactionOperationElem) OperationFee() *big.Int {
 	return big.NewInt(0)
 }
 
+// OperationGasLimit implements OperationWithGasLimit
+func (el *TransactionOperationElem) OperationGasLimit() *big.Int {
+	if el.GasLimit != nil {
+		return &el.GasLimit.Int
+	}
+	return big.NewInt(0)
+}
+
 // TransactionOperationMetadata represents a transaction operation metadata
 type TransactionOperationMetadata struct {
 	BalanceUpdates  BalanceUpdates             `json:"balance_updates" yaml:"balance_updates"`
@@ -148,14 +234,14 @@ type TransactionOperationMetadata struct {
 
 // TransactionOperationResult represents a transaction operation result
 type TransactionOperationResult struct {
-	Status              string                 `json:"status" yaml:"status"`
-	Storage             map[string]interface{} `json:"storage,omitempty" yaml:"storage,omitempty"`
-	BalanceUpdates      BalanceUpdates         `json:"balance_updates,omitempty" yaml:"balance_updates,omitempty"`
-	OriginatedContracts []string               `json:"originated_contracts,omitempty" yaml:"originated_contracts,omitempty"`
-	ConsumedGas         *BigInt                `json:"consumed_gas,omitempty" yaml:"consumed_gas,omitempty"`
-	StorageSize         *BigInt                `json:"storage_size,omitempty" yaml:"storage_size,omitempty"`
-	PaidStorageSizeDiff *BigInt                `json:"paid_storage_size_diff,omitempty" yaml:"paid_storage_size_diff,omitempty"`
-	Errors              Errors                 `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Status              string         `json:"status" yaml:"status"`
+	Storage             interface{}    `json:"storage,omitempty" yaml:"storage,omitempty"`
+	BalanceUpdates      BalanceUpdates `json:"balance_updates,omitempty" yaml:"balance_updates,omitempty"`
+	OriginatedContracts []string       `json:"originated_contracts,omitempty" yaml:"originated_contracts,omitempty"`
+	ConsumedGas         *BigInt        `json:"consumed_gas,omitempty" yaml:"consumed_gas,omitempty"`
+	StorageSize         *BigInt        `json:"storage_size,omitempty" yaml:"storage_size,omitempty"`
+	PaidStorageSizeDiff *BigInt        `json:"paid_storage_size_diff,omitempty" yaml:"paid_storage_size_diff,omitempty"`
+	Errors              Errors         `json:"errors,omitempty" yaml:"errors,omitempty"`
 }
 
 // BallotOperationElem represents a ballot operation
@@ -211,9 +297,9 @@ type InlinedEndorsementContents struct {
 // DoubleEndorsementEvidenceOperationElem represents double_endorsement_evidence operation
 type DoubleEndorsementEvidenceOperationElem struct {
 	GenericOperationElem `yaml:",inline"`
-	Operation1           InlinedEndorsement              `json:"op1" yaml:"op1"`
-	Operation2           InlinedEndorsement              `json:"op2" yaml:"op2"`
-	Metadata             BalanceUpdatesOperationMetadata `json:"metadata" yaml:"metadata"`
+	Operation1           InlinedEndorsement            `json:"op1" yaml:"op1"`
+	Operation2           InlinedEndorsement            `json:"op2" yaml:"op2"`
+	Metadata             DenunciationOperationMetadata `json:"metadata" yaml:"metadata"`
 }
 
 // BalanceUpdates implements BalanceUpdateOperation
@@ -224,9 +310,9 @@ func (el *DoubleEndorsementEvidenceOperationElem) BalanceUpdates() BalanceUpdate
 // DoubleBakingEvidenceOperationElem represents double_baking_evidence operation
 type DoubleBakingEvidenceOperationElem struct {
 	GenericOperationElem `yaml:",inline"`
-	BlockHeader1         RawBlockHeader                  `json:"bh1" yaml:"bh1"`
-	BlockHeader2         RawBlockHeader                  `json:"bh2" yaml:"bh2"`
-	Metadata             BalanceUpdatesOperationMetadata `json:"metadata" yaml:"metadata"`
+	BlockHeader1         RawBlockHeader                `json:"bh1" yaml:"bh1"`
+	BlockHeader2         RawBlockHeader                `json:"bh2" yaml:"bh2"`
+	Metadata             DenunciationOperationMetadata `json:"metadata" yaml:"metadata"`
 }
 
 // BalanceUpdates implements BalanceUpdateOperation
@@ -234,6 +320,40 @@ func (el *DoubleBakingEvidenceOperationElem) BalanceUpdates() BalanceUpdates {
 	return el.Metadata.BalanceUpdates
 }
 
+// AccusedDelegate implements DenunciationOperation
+func (el *DoubleBakingEvidenceOperationElem) AccusedDelegate() string {
+	return el.Metadata.AccusedDelegate()
+}
+
+// AccusedDelegate implements DenunciationOperation
+func (el *DoubleEndorsementEvidenceOperationElem) AccusedDelegate() string {
+	return el.Metadata.AccusedDelegate()
+}
+
+// DenunciationOperationMetadata represents a double signing evidence operation metadata
+type DenunciationOperationMetadata struct {
+	BalanceUpdates    BalanceUpdates `json:"balance_updates" yaml:"balance_updates"`
+	ForbiddenDelegate string         `json:"forbidden_delegate,omitempty" yaml:"forbidden_delegate,omitempty"`
+	PunishedDelegate  string         `json:"punished_delegate,omitempty" yaml:"punished_delegate,omitempty"`
+}
+
+// AccusedDelegate returns the delegate punished for double signing. Before Oxford it's inferred from the slashed deposits.
+// Returns empty string if the metadata is not available.
+func (m *DenunciationOperationMetadata) AccusedDelegate() string {
+	switch {
+	case m.PunishedDelegate != "":
+		return m.PunishedDelegate
+	case m.ForbiddenDelegate != "":
+		return m.ForbiddenDelegate
+	}
+	for _, u := range m.BalanceUpdates {
+		if f, ok := u.(*FreezerBalanceUpdate); ok && f.Change < 0 {
+			return f.FreezerDelegate()
+		}
+	}
+	return ""
+}
+
 // ActivateAccountOperationElem represents activate_account operation
 type ActivateAccountOperationElem struct {
 	GenericOperationElem `yaml:",inline"`
@@ -267,6 +387,14 @@ func (el *RevealOperationElem) OperationFee() *big.Int {
 	return big.NewInt(0)
 }
 
+// OperationGasLimit implements OperationWithGasLimit
+func (el *RevealOperationElem) OperationGasLimit() *big.Int {
+	if el.GasLimit != nil {
+		return &el.GasLimit.Int
+	}
+	return big.NewInt(0)
+}
+
 // BalanceUpdates implements BalanceUpdateOperation
 func (el *RevealOperationElem) BalanceUpdates() BalanceUpdates {
 	return el.Metadata.BalanceUpdates
@@ -300,6 +428,14 @@ func (el *OriginationOperationElem) OperationFee() *big.Int {
 	return big.NewInt(0)
 }
 
+// OperationGasLimit implements OperationWithGasLimit
+func (el *OriginationOperationElem) OperationGasLimit() *big.Int {
+	if el.GasLimit != nil {
+		return &el.GasLimit.Int
+	}
+	return big.NewInt(0)
+}
+
 // BalanceUpdates implements BalanceUpdateOperation
 func (el *OriginationOperationElem) BalanceUpdates() BalanceUpdates {
 	return el.Metadata.BalanceUpdates
@@ -307,8 +443,8 @@ func (el *OriginationOperationElem) BalanceUpdates() BalanceUpdates {
 
 // ScriptedContracts corresponds to $scripted.contracts
 type ScriptedContracts struct {
-	Code    map[string]interface{} `json:"code" yaml:"code"`
-	Storage map[string]interface{} `json:"storage" yaml:"storage"`
+	Code    interface{} `json:"code" yaml:"code"`
+	Storage interface{} `json:"storage" yaml:"storage"`
 }
 
 // OriginationOperationMetadata represents a origination operation metadata
@@ -353,6 +489,14 @@ func (el *DelegationOperationElem) OperationFee() *big.Int {
 	return big.NewInt(0)
 }
 
+// OperationGasLimit implements OperationWithGasLimit
+func (el *DelegationOperationElem) OperationGasLimit() *big.Int {
+	if el.GasLimit != nil {
+		return &el.GasLimit.Int
+	}
+	return big.NewInt(0)
+}
+
 // BalanceUpdates implements BalanceUpdateOperation
 func (el *DelegationOperationElem) BalanceUpdates() BalanceUpdates {
 	return el.Metadata.BalanceUpdates
@@ -370,6 +514,53 @@ type DelegationOperationResult struct {
 	Errors Errors `json:"errors" yaml:"errors"`
 }
 
+// SmartRollupOperationElem represents any of smart_rollup_* manager operations
+type SmartRollupOperationElem struct {
+	GenericOperationElem `yaml:",inline"`
+	Source               string                       `json:"source" yaml:"source"`
+	Fee                  *BigInt                      `json:"fee" yaml:"fee"`
+	Counter              *BigInt                      `json:"counter" yaml:"counter"`
+	GasLimit             *BigInt                      `json:"gas_limit" yaml:"gas_limit"`
+	StorageLimit         *BigInt                      `json:"storage_limit" yaml:"storage_limit"`
+	Rollup               string                       `json:"rollup,omitempty" yaml:"rollup,omitempty"`
+	Metadata             SmartRollupOperationMetadata `json:"metadata" yaml:"metadata"`
+}
+
+// OperationFee implements OperationWithFee
+func (el *SmartRollupOperationElem) OperationFee() *big.Int {
+	if el.Fee != nil {
+		return &el.Fee.Int
+	}
+	return big.NewInt(0)
+}
+
+// OperationGasLimit implements OperationWithGasLimit
+func (el *SmartRollupOperationElem) OperationGasLimit() *big.Int {
+	if el.GasLimit != nil {
+		return &el.GasLimit.Int
+	}
+	return big.NewInt(0)
+}
+
+// BalanceUpdates implements BalanceUpdateOperation
+func (el *SmartRollupOperationElem) BalanceUpdates() BalanceUpdates {
+	return el.Metadata.BalanceUpdates
+}
+
+// SmartRollupOperationMetadata represents a smart rollup operation metadata
+type SmartRollupOperationMetadata struct {
+	BalanceUpdates  BalanceUpdates             `json:"balance_updates" yaml:"balance_updates"`
+	OperationResult SmartRollupOperationResult `json:"operation_result" yaml:"operation_result"`
+}
+
+// SmartRollupOperationResult represents a smart rollup operation result
+type SmartRollupOperationResult struct {
+	Status           string         `json:"status" yaml:"status"`
+	BalanceUpdates   BalanceUpdates `json:"balance_updates,omitempty" yaml:"balance_updates,omitempty"`
+	ConsumedMilligas *BigInt        `json:"consumed_milligas,omitempty" yaml:"consumed_milligas,omitempty"`
+	Errors           Errors         `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
 // BalanceUpdate is a variable structure depending on the Kind field
 type BalanceUpdate interface {
 	BalanceUpdateKind() string
@@ -379,6 +570,7 @@ type BalanceUpdate interface {
 type GenericBalanceUpdate struct {
 	Kind   string `json:"kind" yaml:"kind"`
 	Change int64  `json:"change,string" yaml:"change"`
+	Origin string `json:"origin" yaml:"origin"`
 }
 
 // BalanceUpdateKind returns the BalanceUpdateType's Kind field
@@ -394,10 +586,40 @@ type ContractBalanceUpdate struct {
 
 // FreezerBalanceUpdate is a BalanceUpdatesType variant for Kind=freezer
 type FreezerBalanceUpdate struct {
+	GenericBalanceUpdate `yaml:",inline"`
+	Category             string               `json:"category" yaml:"category"`
+	Delegate             string               `json:"delegate" yaml:"delegate"`
+	Level                int                  `json:"level" yaml:"level"`
+	Staker               *BalanceUpdateStaker `json:"staker" yaml:"staker"`
+}
+
+// BalanceUpdateStaker identifies the owner of frozen deposits since Oxford
+type BalanceUpdateStaker struct {
+	Contract      string `json:"contract" yaml:"contract"`
+	Delegate      string `json:"delegate" yaml:"delegate"`
+	BakerOwnStake string `json:"baker_own_stake" yaml:"baker_own_stake"`
+	BakerEdge     string `json:"baker_edge" yaml:"baker_edge"`
+}
+
+// FreezerDelegate returns the delegate the frozen balance belongs to
+func (f *FreezerBalanceUpdate) FreezerDelegate() string {
+	switch {
+	case f.Delegate != "":
+		return f.Delegate
+	case f.Staker == nil:
+		return ""
+	case f.Staker.BakerOwnStake != "":
+		return f.Staker.BakerOwnStake
+	case f.Staker.BakerEdge != "":
+		return f.Staker.BakerEdge
+	}
+	return f.Staker.Delegate
+}
+
+// CategoryBalanceUpdate is a BalanceUpdatesType variant for Kind=minted, Kind=burned, Kind=accumulator and Kind=commitment
+type CategoryBalanceUpdate struct {
 	GenericBalanceUpdate `yaml:",inline"`
 	Category             string `json:"category" yaml:"category"`
-	Delegate             string `json:"delegate" yaml:"delegate"`
-	Level                int    `json:"level" yaml:"level"`
 }
 
 // BalanceUpdates is a list of balance update operations
@@ -427,6 +649,9 @@ opLoop:
 		case "freezer":
 			(*b)[i] = &FreezerBalanceUpdate{}
 
+		case "minted", "burned", "accumulator", "commitment":
+			(*b)[i] = &CategoryBalanceUpdate{}
+
 		default:
 			(*b)[i] = &tmp
 			continue opLoop
@@ -503,4 +728,9 @@ var (
 	_ OperationWithFee = &RevealOperationElem{}
 	_ OperationWithFee = &OriginationOperationElem{}
 	_ OperationWithFee = &DelegationOperationElem{}
+
+	_ OperationWithGasLimit = &TransactionOperationElem{}
+	_ OperationWithGasLimit = &RevealOperationElem{}
+	_ OperationWithGasLimit = &OriginationOperationElem{}
+	_ OperationWithGasLimit = &DelegationOperationElem{}
 )
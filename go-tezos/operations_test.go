@@ -0,0 +1,117 @@
+package tezos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndorsementPower(t *testing.T) {
+	tests := []struct {
+		src   string
+		power int
+	}{
+		{src: `[{"kind":"endorsement","level":1,"metadata":{"delegate":"tz1","slots":[1,5,7]}}]`, power: 3},
+		{src: `[{"kind":"endorsement","level":1,"metadata":{"delegate":"tz1","endorsement_power":120}}]`, power: 120},
+		{src: `[{"kind":"attestation","level":1,"metadata":{"delegate":"tz1","consensus_power":42}}]`, power: 42},
+		{src: `[{"kind":"attestation_with_dal","level":1,"metadata":{"delegate":"tz1","consensus_power":{"slots":17,"baking_power":"100"}}}]`, power: 17},
+	}
+
+	for _, test := range tests {
+		var ops OperationElements
+		require.NoError(t, json.Unmarshal([]byte(test.src), &ops))
+		require.Len(t, ops, 1)
+		el, ok := ops[0].(*EndorsementOperationElem)
+		require.True(t, ok)
+		require.Equal(t, test.power, el.Power())
+	}
+}
+
+func TestPreendorsementPower(t *testing.T) {
+	tests := []struct {
+		src   string
+		power int
+	}{
+		{src: `[{"kind":"preendorsement","slot":3,"level":1,"round":1,"block_payload_hash":"vh1","metadata":{"delegate":"tz1","preendorsement_power":25}}]`, power: 25},
+		{src: `[{"kind":"preattestation","slot":3,"level":1,"round":1,"block_payload_hash":"vh1","metadata":{"delegate":"tz1","consensus_power":42}}]`, power: 42},
+	}
+
+	for _, test := range tests {
+		var ops OperationElements
+		require.NoError(t, json.Unmarshal([]byte(test.src), &ops))
+		require.Len(t, ops, 1)
+		el, ok := ops[0].(*PreendorsementOperationElem)
+		require.True(t, ok)
+		require.Equal(t, 1, el.Round)
+		require.Equal(t, test.power, el.PreendorsementPower())
+	}
+}
+
+func TestFreezerDelegate(t *testing.T) {
+	tests := []struct {
+		src      string
+		delegate string
+	}{
+		{src: `[{"kind":"freezer","category":"deposits","delegate":"tz1a","change":"1","origin":"block"}]`, delegate: "tz1a"},
+		{src: `[{"kind":"freezer","category":"deposits","staker":{"baker_own_stake":"tz1b"},"change":"1","origin":"block"}]`, delegate: "tz1b"},
+		{src: `[{"kind":"freezer","category":"deposits","staker":{"baker_edge":"tz1c"},"change":"1","origin":"block"}]`, delegate: "tz1c"},
+		{src: `[{"kind":"freezer","category":"deposits","staker":{"contract":"tz1x","delegate":"tz1d"},"change":"1","origin":"block"}]`, delegate: "tz1d"},
+	}
+
+	for _, test := range tests {
+		var updates BalanceUpdates
+		require.NoError(t, json.Unmarshal([]byte(test.src), &updates))
+		require.Len(t, updates, 1)
+		u, ok := updates[0].(*FreezerBalanceUpdate)
+		require.True(t, ok)
+		require.Equal(t, "block", u.Origin)
+		require.Equal(t, test.delegate, u.FreezerDelegate())
+	}
+}
+
+func TestSmartRollupOperations(t *testing.T) {
+	src := `[
+		{"kind":"smart_rollup_publish","source":"tz1a","fee":"1000","counter":"5","gas_limit":"6000","storage_limit":"0","rollup":"sr1a","commitment":{"compressed_state":"srs1","inbox_level":10,"predecessor":"src1","number_of_ticks":"100"},
+			"metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"5000000","staked_hash":"src2","published_at_level":12}}},
+		{"kind":"smart_rollup_refute","source":"tz1b","fee":"2000","counter":"6","gas_limit":"7000","storage_limit":"0","rollup":"sr1a","opponent":"tz1a","refutation":{"refutation_kind":"start"},
+			"metadata":{"balance_updates":[],"operation_result":{"status":"failed","errors":[{"kind":"temporary","id":"proto.alpha.smart_rollup_no_conflict"}]}}}
+	]`
+
+	var ops OperationElements
+	require.NoError(t, json.Unmarshal([]byte(src), &ops))
+	require.Len(t, ops, 2)
+
+	publish, ok := ops[0].(*SmartRollupOperationElem)
+	require.True(t, ok)
+	require.Equal(t, "smart_rollup_publish", publish.OperationElemKind())
+	require.Equal(t, "sr1a", publish.Rollup)
+	require.Equal(t, int64(1000), publish.OperationFee().Int64())
+	require.Equal(t, "applied", publish.Metadata.OperationResult.Status)
+
+	refute, ok := ops[1].(*SmartRollupOperationElem)
+	require.True(t, ok)
+	require.Equal(t, "failed", refute.Metadata.OperationResult.Status)
+	require.Len(t, refute.Metadata.OperationResult.Errors, 1)
+}
+
+func TestAccusedDelegate(t *testing.T) {
+	tests := []struct {
+		src      string
+		delegate string
+	}{
+		{src: `[{"kind":"double_baking_evidence","bh1":{"level":1},"bh2":{"level":1},"metadata":{"balance_updates":[{"kind":"freezer","category":"deposits","delegate":"tz1a","cycle":10,"change":"-512000000"},{"kind":"freezer","category":"rewards","delegate":"tz1b","cycle":10,"change":"256000000"}]}}]`, delegate: "tz1a"},
+		{src: `[{"kind":"double_attestation_evidence","op1":{"branch":"BL"},"op2":{"branch":"BL"},"metadata":{"forbidden_delegate":"tz1c","balance_updates":[]}}]`, delegate: "tz1c"},
+		{src: `[{"kind":"double_preattestation_evidence","op1":{"branch":"BL"},"op2":{"branch":"BL"},"metadata":{"punished_delegate":"tz1d","rewarded_delegate":"tz1e","misbehaviour":{"level":1,"round":0,"kind":"preattestation"}}}]`, delegate: "tz1d"},
+		{src: `[{"kind":"double_baking_evidence","bh1":{"level":1},"bh2":{"level":1}}]`, delegate: ""},
+	}
+
+	for _, test := range tests {
+		var ops OperationElements
+		require.NoError(t, json.Unmarshal([]byte(test.src), &ops))
+		require.Len(t, ops, 1)
+		el, ok := ops[0].(DenunciationOperation)
+		require.True(t, ok)
+		require.Equal(t, test.delegate, el.AccusedDelegate())
+	}
+}
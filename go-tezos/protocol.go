@@ -0,0 +1,97 @@
+package tezos
+
+import (
+	"encoding/binary"
+	"strings"
+)
+
+// ProtocolCapabilities describes protocol dependent RPC and data model features
+type ProtocolCapabilities struct {
+	Protocol string
+	// Tenderbake consensus with rounds instead of priorities, introduced in Ithaca
+	Tenderbake bool
+	// Consensus operations are called attestations since Oxford
+	Attestations bool
+}
+
+var (
+	emmyProtocols = []string{
+		"PsYLVpVv", "PtCJ7pwo", "Pt24m4xi", "PsBabyM1", "PsBABY5H", "PsCARTHA", "PsDELPH1",
+		"PtEdoTez", "PtEdo2Zk", "PsFLoren", "PtGRANAD", "PtHangz2", "PtHangzH",
+	}
+	endorsementProtocols = []string{
+		"Psithaca", "PtJakart", "PtKathma", "PtLimaPt", "PtMumbai", "PtNairob",
+	}
+)
+
+func hasProtocolPrefix(protocol string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(protocol, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetProtocolCapabilities returns capabilities of the protocol with the given hash.
+// Unknown protocols are assumed to be newer than all known ones.
+func GetProtocolCapabilities(protocol string) *ProtocolCapabilities {
+	switch {
+	case hasProtocolPrefix(protocol, emmyProtocols):
+		return &ProtocolCapabilities{Protocol: protocol}
+	case hasProtocolPrefix(protocol, endorsementProtocols):
+		return &ProtocolCapabilities{Protocol: protocol, Tenderbake: true}
+	default:
+		return &ProtocolCapabilities{Protocol: protocol, Tenderbake: true, Attestations: true}
+	}
+}
+
+// EndorsementKind returns the consensus operation kind
+func (p *ProtocolCapabilities) EndorsementKind() string {
+	if p.Attestations {
+		return "attestation"
+	}
+	return "endorsement"
+}
+
+// PreendorsementKind returns the Tenderbake preliminary consensus operation kind
+func (p *ProtocolCapabilities) PreendorsementKind() string {
+	if p.Attestations {
+		return "preattestation"
+	}
+	return "preendorsement"
+}
+
+// EndorsingRightsRPC returns the name of the consensus rights RPC
+func (p *ProtocolCapabilities) EndorsingRightsRPC() string {
+	if p.Attestations {
+		return "attestation_rights"
+	}
+	return "endorsing_rights"
+}
+
+// BlockRound returns the block priority (Emmy) or round (Tenderbake)
+func (p *ProtocolCapabilities) BlockRound(header *RawBlockHeader) int {
+	if !p.Tenderbake {
+		return header.Priority
+	}
+	// The round is the last fitness element
+	if len(header.Fitness) == 0 {
+		return 0
+	}
+	if round := header.Fitness[len(header.Fitness)-1]; len(round) == 4 {
+		return int(binary.BigEndian.Uint32(round))
+	}
+	return 0
+}
+
+// PredecessorRound returns the round of the block's predecessor encoded in the Tenderbake fitness.
+// False is returned for Emmy blocks which don't carry it.
+func (p *ProtocolCapabilities) PredecessorRound(header *RawBlockHeader) (int, bool) {
+	// version, level, locked round, predecessor round, round
+	if !p.Tenderbake || len(header.Fitness) != 5 || len(header.Fitness[3]) != 4 {
+		return 0, false
+	}
+	// The predecessor round is stored as -1 - round
+	return int(-1 - int32(binary.BigEndian.Uint32(header.Fitness[3]))), true
+}
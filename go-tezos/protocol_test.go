@@ -0,0 +1,73 @@
+package tezos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtocolCapabilities(t *testing.T) {
+	tests := []struct {
+		protocol        string
+		endorsementKind string
+		header          RawBlockHeader
+		round           int
+	}{
+		{
+			protocol:        "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt",
+			endorsementKind: "endorsement",
+			header:          RawBlockHeader{Priority: 2, Fitness: []HexBytes{{0x0}, {0x0, 0x0, 0x0, 0x0, 0x0, 0x5a, 0x12, 0x5f}}},
+			round:           2,
+		},
+		{
+			protocol:        "PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf",
+			endorsementKind: "endorsement",
+			header:          RawBlockHeader{Fitness: []HexBytes{{0x02}, {0x0, 0x3b, 0x5a, 0x12}, {}, {0xff, 0xff, 0xff, 0xff}, {0x0, 0x0, 0x0, 0x1}}},
+			round:           1,
+		},
+		{
+			protocol:        "PsQuebecnLByd3JwTiGadoG4nGWi3HYiLXUjkibeFV8dCFeVMUg",
+			endorsementKind: "attestation",
+			header:          RawBlockHeader{Fitness: []HexBytes{{0x02}, {0x0, 0x6d, 0x5a, 0x12}, {}, {0xff, 0xff, 0xff, 0xff}, {0x0, 0x0, 0x0, 0x0}}},
+			round:           0,
+		},
+	}
+
+	for _, test := range tests {
+		caps := GetProtocolCapabilities(test.protocol)
+		require.Equal(t, test.endorsementKind, caps.EndorsementKind())
+		require.Equal(t, test.round, caps.BlockRound(&test.header))
+	}
+}
+
+func TestPredecessorRound(t *testing.T) {
+	tests := []struct {
+		protocol string
+		header   RawBlockHeader
+		round    int
+		ok       bool
+	}{
+		{
+			protocol: "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt",
+			header:   RawBlockHeader{Priority: 2, Fitness: []HexBytes{{0x0}, {0x0, 0x0, 0x0, 0x0, 0x0, 0x5a, 0x12, 0x5f}}},
+		},
+		{
+			protocol: "PsQuebecnLByd3JwTiGadoG4nGWi3HYiLXUjkibeFV8dCFeVMUg",
+			header:   RawBlockHeader{Fitness: []HexBytes{{0x02}, {0x0, 0x6d, 0x5a, 0x12}, {}, {0xff, 0xff, 0xff, 0xff}, {0x0, 0x0, 0x0, 0x1}}},
+			round:    0,
+			ok:       true,
+		},
+		{
+			protocol: "PsQuebecnLByd3JwTiGadoG4nGWi3HYiLXUjkibeFV8dCFeVMUg",
+			header:   RawBlockHeader{Fitness: []HexBytes{{0x02}, {0x0, 0x6d, 0x5a, 0x12}, {0x0, 0x0, 0x0, 0x0}, {0xff, 0xff, 0xff, 0xfd}, {0x0, 0x0, 0x0, 0x0}}},
+			round:    2,
+			ok:       true,
+		},
+	}
+
+	for _, test := range tests {
+		round, ok := GetProtocolCapabilities(test.protocol).PredecessorRound(&test.header)
+		require.Equal(t, test.ok, ok)
+		require.Equal(t, test.round, round)
+	}
+}
@@ -0,0 +1,25 @@
+package tezos
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ProxyFunc returns the proxy selection function of the transport. The explicitly given proxy is used for all requests,
+// empty proxy URL selects the proxy by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func ProxyFunc(proxy string) (func(*http.Request) (*url.URL, error), error) {
+	if proxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("tezos: unsupported proxy scheme in %s", u.Redacted())
+	}
+	return http.ProxyURL(u), nil
+}
@@ -0,0 +1,83 @@
+package tezos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// BakingRight holds information about a delegate's right to bake a block at the given level and round
+type BakingRight struct {
+	Level         int        `json:"level" yaml:"level"`
+	Delegate      string     `json:"delegate" yaml:"delegate"`
+	Round         int        `json:"round" yaml:"round"`
+	EstimatedTime *time.Time `json:"estimated_time,omitempty" yaml:"estimated_time,omitempty"`
+	ConsensusKey  string     `json:"consensus_key,omitempty" yaml:"consensus_key,omitempty"`
+}
+
+// BakingRightsOptions holds the baking rights query filters. Zero values are omitted.
+type BakingRightsOptions struct {
+	Delegate string
+	Level    *int
+	Cycle    *int
+	MaxRound *int
+}
+
+// EndorsingRights holds the endorsing rights of all delegates at the given level
+type EndorsingRights struct {
+	Level         int                       `json:"level" yaml:"level"`
+	Delegates     []*DelegateEndorsingRight `json:"delegates" yaml:"delegates"`
+	EstimatedTime *time.Time                `json:"estimated_time,omitempty" yaml:"estimated_time,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Before Ithaca the rights were reported per level and delegate
+// along with the list of slots, such entries are converted to a single element Delegates list with the power equal to the number of slots.
+func (e *EndorsingRights) UnmarshalJSON(data []byte) error {
+	type endorsingRights EndorsingRights
+	var tmp struct {
+		endorsingRights
+		Delegate string `json:"delegate"`
+		Slots    []int  `json:"slots"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*e = EndorsingRights(tmp.endorsingRights)
+
+	if tmp.Delegate != "" && e.Delegates == nil {
+		right := DelegateEndorsingRight{
+			Delegate:       tmp.Delegate,
+			EndorsingPower: len(tmp.Slots),
+		}
+		for i, slot := range tmp.Slots {
+			if i == 0 || slot < right.FirstSlot {
+				right.FirstSlot = slot
+			}
+		}
+		e.Delegates = []*DelegateEndorsingRight{&right}
+	}
+	return nil
+}
+
+// DelegateEndorsingRight holds the endorsing (attestation since Oxford) power of a delegate at the given level
+type DelegateEndorsingRight struct {
+	Delegate         string `json:"delegate" yaml:"delegate"`
+	FirstSlot        int    `json:"first_slot" yaml:"first_slot"`
+	EndorsingPower   int    `json:"endorsing_power" yaml:"endorsing_power"`
+	AttestationPower int    `json:"attestation_power" yaml:"attestation_power"`
+	ConsensusKey     string `json:"consensus_key,omitempty" yaml:"consensus_key,omitempty"`
+}
+
+// Power returns the endorsing power
+func (d *DelegateEndorsingRight) Power() int {
+	if d.AttestationPower != 0 {
+		return d.AttestationPower
+	}
+	return d.EndorsingPower
+}
+
+// EndorsingRightsOptions holds the endorsing rights query filters. Zero values are omitted.
+type EndorsingRightsOptions struct {
+	Delegate string
+	Cycle    *int
+	Level    *int
+}
@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -18,6 +19,20 @@ type Service struct {
 	Client *RPCClient
 }
 
+// adminAction logs the node state mutation and passes it to the client's AdminActionHandler
+func (s *Service) adminAction(action, target string, err error) error {
+	l := s.Client.log().WithFields(map[string]interface{}{"action": action, "target": target})
+	if err != nil {
+		l.WithError(err).Warn("node admin action failed")
+	} else {
+		l.Info("node admin action")
+	}
+	if s.Client.AdminActionHandler != nil {
+		s.Client.AdminActionHandler(action, target, err)
+	}
+	return err
+}
+
 // NetworkStats models global network bandwidth totals and usage in B/s.
 type NetworkStats struct {
 	TotalBytesSent int64 `json:"total_sent,string"`
@@ -28,14 +43,35 @@ type NetworkStats struct {
 
 // NetworkConnection models detailed information for one network connection.
 type NetworkConnection struct {
-	Incoming         bool              `json:"incoming"`
-	PeerID           string            `json:"peer_id"`
-	IDPoint          NetworkAddress    `json:"id_point"`
-	RemoteSocketPort uint16            `json:"remote_socket_port"`
-	Versions         []*NetworkVersion `json:"versions"`
-	Private          bool              `json:"private"`
-	LocalMetadata    NetworkMetadata   `json:"local_metadata"`
-	RemoteMetadata   NetworkMetadata   `json:"remote_metadata"`
+	Incoming         bool                     `json:"incoming"`
+	PeerID           string                   `json:"peer_id"`
+	IDPoint          NetworkAddress           `json:"id_point"`
+	RemoteSocketPort uint16                   `json:"remote_socket_port"`
+	Versions         []*NetworkVersion        `json:"versions"`
+	AnnouncedVersion *NetworkAnnouncedVersion `json:"announced_version"`
+	Private          bool                     `json:"private"`
+	LocalMetadata    NetworkMetadata          `json:"local_metadata"`
+	RemoteMetadata   NetworkMetadata          `json:"remote_metadata"`
+}
+
+// Version returns the version announced by the peer. Newer nodes report a single announced_version object instead of the versions list.
+// If the list contains more than one entry then the greatest one is returned.
+func (n *NetworkConnection) Version() *NetworkVersion {
+	if n.AnnouncedVersion != nil {
+		return &NetworkVersion{
+			Name:  n.AnnouncedVersion.ChainName,
+			Major: n.AnnouncedVersion.DistributedDBVersion,
+			Minor: n.AnnouncedVersion.P2PVersion,
+		}
+	}
+
+	var ver *NetworkVersion
+	for _, v := range n.Versions {
+		if ver == nil || v.Major > ver.Major || v.Major == ver.Major && v.Minor > ver.Minor {
+			ver = v
+		}
+	}
+	return ver
 }
 
 // NetworkAddress models a point's address and port.
@@ -51,6 +87,13 @@ type NetworkVersion struct {
 	Minor uint16 `json:"minor"`
 }
 
+// NetworkAnnouncedVersion models a network version announced by newer nodes.
+type NetworkAnnouncedVersion struct {
+	ChainName            string `json:"chain_name"`
+	DistributedDBVersion uint16 `json:"distributed_db_version"`
+	P2PVersion           uint16 `json:"p2p_version"`
+}
+
 // NetworkMetadata models metadata of a node.
 type NetworkMetadata struct {
 	DisableMempool bool `json:"disable_mempool"`
@@ -63,6 +106,15 @@ type BootstrappedBlock struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// ActiveChain represents an element of the active chains stream message. Either ChainID or Stopping is set,
+// TestProtocol and ExpirationDate are set for test chains only.
+type ActiveChain struct {
+	ChainID        string     `json:"chain_id,omitempty" yaml:"chain_id,omitempty"`
+	TestProtocol   string     `json:"test_protocol,omitempty" yaml:"test_protocol,omitempty"`
+	ExpirationDate *time.Time `json:"expiration_date,omitempty" yaml:"expiration_date,omitempty"`
+	Stopping       string     `json:"stopping,omitempty" yaml:"stopping,omitempty"`
+}
+
 // NetworkConnectionTimestamp represents peer address with timestamp added
 type NetworkConnectionTimestamp struct {
 	NetworkAddress
@@ -181,6 +233,30 @@ type BootstrappedStatus struct {
 	SyncState    SyncState `json:"sync_state"`
 }
 
+// BlockLevel identifies a block of the chain's history such as the savepoint or caboose
+type BlockLevel struct {
+	BlockHash string `json:"block_hash" yaml:"block_hash"`
+	Level     int    `json:"level" yaml:"level"`
+}
+
+// HistoryMode is the storage mode of the node
+type HistoryMode string
+
+const (
+	HistoryModeArchive HistoryMode = "archive"
+	HistoryModeFull    HistoryMode = "full"
+	HistoryModeRolling HistoryMode = "rolling"
+)
+
+// Checkpoint is returned by the legacy checkpoint RPC which predates the levels RPCs.
+// The block header lacks the hash.
+type Checkpoint struct {
+	Block       BlockInfo   `json:"block" yaml:"block"`
+	SavePoint   int         `json:"save_point" yaml:"save_point"`
+	Caboose     int         `json:"caboose" yaml:"caboose"`
+	HistoryMode HistoryMode `json:"history_mode" yaml:"history_mode"`
+}
+
 type proposalsRPCResponse = [][]interface{}
 
 // BigInt overrides UnmarshalJSON for big.Int
@@ -235,6 +311,21 @@ func (s *Service) GetNetworkConnections(ctx context.Context) ([]*NetworkConnecti
 	return conns, err
 }
 
+// GetNetworkSelf returns the peer ID of the node.
+// https://tezos.gitlab.io/active/rpc.html#get-network-self
+func (s *Service) GetNetworkSelf(ctx context.Context) (string, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/network/self", nil)
+	if err != nil {
+		return "", err
+	}
+
+	var id string
+	if err = s.Client.Do(req, &id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
 // GetNetworkPeers returns the list the peers the node ever met.
 // https://tezos.gitlab.io/mainnet/api/rpc.html#get-network-peers
 func (s *Service) GetNetworkPeers(ctx context.Context, filter string) ([]*NetworkPeer, error) {
@@ -292,10 +383,7 @@ func (s *Service) BanNetworkPeer(ctx context.Context, peerID string) error {
 		return err
 	}
 
-	if err := s.Client.Do(req, nil); err != nil {
-		return err
-	}
-	return nil
+	return s.adminAction("ban_peer", peerID, s.Client.Do(req, nil))
 }
 
 // TrustNetworkPeer used to trust a given peer permanently: the peer cannot be blocked (but its host IP still can).
@@ -306,10 +394,18 @@ func (s *Service) TrustNetworkPeer(ctx context.Context, peerID string) error {
 		return err
 	}
 
-	if err := s.Client.Do(req, nil); err != nil {
+	return s.adminAction("trust_peer", peerID, s.Client.Do(req, nil))
+}
+
+// ClearNetworkGreylist clears the greylists of peers and points.
+// https://tezos.gitlab.io/active/rpc.html#delete-network-greylist
+func (s *Service) ClearNetworkGreylist(ctx context.Context) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, "/network/greylist", nil)
+	if err != nil {
 		return err
 	}
-	return nil
+
+	return s.adminAction("clear_greylist", "", s.Client.Do(req, nil))
 }
 
 // GetNetworkPeerBanned checks if a given peer is blacklisted or greylisted.
@@ -423,11 +519,7 @@ func (s *Service) ConnectToNetworkPoint(ctx context.Context, address string, tim
 		return err
 	}
 
-	if err := s.Client.Do(req, nil); err != nil {
-		return err
-	}
-
-	return nil
+	return s.adminAction("connect_point", address, s.Client.Do(req, nil))
 }
 
 // BanNetworkPoint blacklists the given address.
@@ -438,10 +530,7 @@ func (s *Service) BanNetworkPoint(ctx context.Context, address string) error {
 		return err
 	}
 
-	if err := s.Client.Do(req, nil); err != nil {
-		return err
-	}
-	return nil
+	return s.adminAction("ban_point", address, s.Client.Do(req, nil))
 }
 
 // TrustNetworkPoint used to trust a given address permanently. Connections from this address can still be closed on authentication if the peer is blacklisted or greylisted.
@@ -452,10 +541,7 @@ func (s *Service) TrustNetworkPoint(ctx context.Context, address string) error {
 		return err
 	}
 
-	if err := s.Client.Do(req, nil); err != nil {
-		return err
-	}
-	return nil
+	return s.adminAction("trust_point", address, s.Client.Do(req, nil))
 }
 
 // GetNetworkPointBanned check is a given address is blacklisted or greylisted.
@@ -501,6 +587,26 @@ func (s *Service) MonitorNetworkPointLog(ctx context.Context, address string, re
 	return s.Client.Do(req, results)
 }
 
+// GetDelegates returns the public key hashes of all registered delegates or the active ones only
+// https://tezos.gitlab.io/active/rpc.html#get-block-id-context-delegates
+func (s *Service) GetDelegates(ctx context.Context, chainID string, blockID string, activeOnly bool) ([]string, error) {
+	u := "/chains/" + chainID + "/blocks/" + blockID + "/context/delegates"
+	if activeOnly {
+		u += "?active"
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var delegates []string
+	if err := s.Client.Do(req, &delegates); err != nil {
+		return nil, err
+	}
+
+	return delegates, nil
+}
+
 // GetDelegateBalance returns a delegate's balance http://tezos.gitlab.io/mainnet/api/rpc.html#get-block-id-context-delegates-pkh-balance
 func (s *Service) GetDelegateBalance(ctx context.Context, chainID string, blockID string, pkh string) (*big.Int, error) {
 	u := "/chains/" + chainID + "/blocks/" + blockID + "/context/delegates/" + pkh + "/balance"
@@ -517,6 +623,38 @@ func (s *Service) GetDelegateBalance(ctx context.Context, chainID string, blockI
 	return (*big.Int)(&balance.Int), nil
 }
 
+// GetDelegateStakingBalance returns the total amount of tokens delegated to or staked with a delegate, including its own balance
+func (s *Service) GetDelegateStakingBalance(ctx context.Context, chainID string, blockID string, pkh string) (*big.Int, error) {
+	u := "/chains/" + chainID + "/blocks/" + blockID + "/context/delegates/" + pkh + "/staking_balance"
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance BigInt
+	if err := s.Client.Do(req, &balance); err != nil {
+		return nil, err
+	}
+
+	return (*big.Int)(&balance.Int), nil
+}
+
+// GetDelegateDelegatedBalance returns the balance of all the contracts that delegate to a delegate, excluding its own balance
+func (s *Service) GetDelegateDelegatedBalance(ctx context.Context, chainID string, blockID string, pkh string) (*big.Int, error) {
+	u := "/chains/" + chainID + "/blocks/" + blockID + "/context/delegates/" + pkh + "/delegated_balance"
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance BigInt
+	if err := s.Client.Do(req, &balance); err != nil {
+		return nil, err
+	}
+
+	return (*big.Int)(&balance.Int), nil
+}
+
 // GetContractBalance returns a contract's balance http://tezos.gitlab.io/mainnet/api/rpc.html#get-block-id-context-contracts-contract-id-balance
 func (s *Service) GetContractBalance(ctx context.Context, chainID string, blockID string, contractID string) (*big.Int, error) {
 	u := "/chains/" + chainID + "/blocks/" + blockID + "/context/contracts/" + contractID + "/balance"
@@ -553,6 +691,49 @@ func (s *Service) MonitorHeads(ctx context.Context, chainID string, results chan
 	return s.Client.Do(req, results)
 }
 
+// MonitorProtocols reads the hashes of the protocols as soon as the node learns them, either from the network or by activation
+// https://tezos.gitlab.io/active/rpc.html#get-monitor-protocols
+func (s *Service) MonitorProtocols(ctx context.Context, results chan<- string) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/monitor/protocols", nil)
+	if err != nil {
+		return err
+	}
+
+	return s.Client.Do(req, results)
+}
+
+// MonitorActiveChains reads the list of active chains every time a chain is started or stopped, e.g. when a test chain is forked
+// https://tezos.gitlab.io/active/rpc.html#get-monitor-active-chains
+func (s *Service) MonitorActiveChains(ctx context.Context, results chan<- []*ActiveChain) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/monitor/active_chains", nil)
+	if err != nil {
+		return err
+	}
+
+	return s.Client.Do(req, results)
+}
+
+// MonitorValidBlocks subscribes to the blocks successfully validated by the node, including the ones which never become head.
+// Non empty protocolFilter limits the stream to the blocks of the given protocol.
+// https://tezos.gitlab.io/active/rpc.html#get-monitor-valid-blocks
+func (s *Service) MonitorValidBlocks(ctx context.Context, chainID, protocolFilter string, results chan<- *ValidBlock) error {
+	q := url.Values{"chains": []string{chainID}}
+	if protocolFilter != "" {
+		q.Set("protocol", protocolFilter)
+	}
+	u := url.URL{
+		Path:     "/monitor/valid_blocks",
+		RawQuery: q.Encode(),
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.Client.Do(req, results)
+}
+
 // GetMempoolPendingOperations returns mempool pending operations
 func (s *Service) GetMempoolPendingOperations(ctx context.Context, chainID string) (*MempoolOperations, error) {
 	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/mempool/pending_operations", nil)
@@ -568,14 +749,31 @@ func (s *Service) GetMempoolPendingOperations(ctx context.Context, chainID strin
 	return &ops, nil
 }
 
-// MonitorMempoolOperations monitors mempool pending operations.
+// MonitorMempoolOperations monitors mempool pending operations of a single pool using the older query form.
 // The connection is closed after every new block.
 func (s *Service) MonitorMempoolOperations(ctx context.Context, chainID, filter string, results chan<- []*Operation) error {
 	if filter == "" {
-		filter = "applied"
+		filter = PoolApplied
+	}
+	return s.MonitorMempool(ctx, chainID, &MempoolFilter{Pools: []string{filter}}, results)
+}
+
+// MonitorMempool monitors mempool pending operations of the pools selected by the filter in a single stream.
+// The connection is closed after every new block.
+// https://tezos.gitlab.io/active/rpc.html#get-chains-chain-id-mempool-monitor-operations
+func (s *Service) MonitorMempool(ctx context.Context, chainID string, filter *MempoolFilter, results chan<- []*Operation) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/mempool/monitor_operations?"+filter.Query(), nil)
+	if err != nil {
+		return err
 	}
 
-	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/mempool/monitor_operations?"+filter, nil)
+	return s.Client.Do(req, results)
+}
+
+// MonitorMempoolWithErrors is like MonitorMempool but retains the errors of the operations which weren't validated.
+// Use MempoolPool to classify the operations of a multi-pool stream.
+func (s *Service) MonitorMempoolWithErrors(ctx context.Context, chainID string, filter *MempoolFilter, results chan<- []*OperationWithError) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/mempool/monitor_operations?"+filter.Query(), nil)
 	if err != nil {
 		return err
 	}
@@ -599,10 +797,41 @@ func (s *Service) GetInvalidBlocks(ctx context.Context, chainID string) ([]*Inva
 	return invalidBlocks, nil
 }
 
+// Block metadata modes
+const (
+	MetadataAlways = "always"
+	MetadataNever  = "never"
+)
+
+// BlockOptions holds the block request options supported by newer nodes
+type BlockOptions struct {
+	Metadata      string // MetadataAlways, MetadataNever or empty for the node default
+	ForceMetadata bool   // Return metadata even if it's too large to be stored by default
+}
+
 // GetBlock returns information about a Tezos block
 // https://tezos.gitlab.io/alphanet/api/rpc.html#get-block-id
 func (s *Service) GetBlock(ctx context.Context, chainID, blockID string) (*Block, error) {
-	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/blocks/"+blockID, nil)
+	return s.GetBlockWithOptions(ctx, chainID, blockID, nil)
+}
+
+// GetBlockWithOptions is like GetBlock but allows to control the metadata
+func (s *Service) GetBlockWithOptions(ctx context.Context, chainID, blockID string, opts *BlockOptions) (*Block, error) {
+	u := url.URL{
+		Path: "/chains/" + chainID + "/blocks/" + blockID,
+	}
+	if opts != nil {
+		q := make(url.Values)
+		if opts.Metadata != "" {
+			q.Set("metadata", opts.Metadata)
+		}
+		if opts.ForceMetadata {
+			q.Set("force_metadata", "true")
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -615,6 +844,125 @@ func (s *Service) GetBlock(ctx context.Context, chainID, blockID string) (*Block
 	return &block, nil
 }
 
+// GetBlockHeader returns the header of a Tezos block
+// https://tezos.gitlab.io/alphanet/api/rpc.html#get-block-id-header
+func (s *Service) GetBlockHeader(ctx context.Context, chainID, blockID string) (*BlockHeader, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/blocks/"+blockID+"/header", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var header BlockHeader
+	if err := s.Client.Do(req, &header); err != nil {
+		return nil, err
+	}
+
+	return &header, nil
+}
+
+// GetConstants returns the protocol constants
+// https://tezos.gitlab.io/active/rpc.html#get-block-id-context-constants
+func (s *Service) GetConstants(ctx context.Context, chainID, blockID string) (*Constants, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/blocks/"+blockID+"/context/constants", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var constants Constants
+	if err := s.Client.Do(req, &constants); err != nil {
+		return nil, err
+	}
+
+	return &constants, nil
+}
+
+// GetBakingRights returns the baking rights of delegates
+// https://tezos.gitlab.io/active/rpc.html#get-block-id-helpers-baking-rights
+func (s *Service) GetBakingRights(ctx context.Context, chainID, blockID string, opts *BakingRightsOptions) ([]*BakingRight, error) {
+	u := url.URL{
+		Path: "/chains/" + chainID + "/blocks/" + blockID + "/helpers/baking_rights",
+	}
+
+	if opts != nil {
+		q := url.Values{}
+		if opts.Delegate != "" {
+			q.Set("delegate", opts.Delegate)
+		}
+		if opts.Level != nil {
+			q.Set("level", strconv.Itoa(*opts.Level))
+		}
+		if opts.Cycle != nil {
+			q.Set("cycle", strconv.Itoa(*opts.Cycle))
+		}
+		if opts.MaxRound != nil {
+			q.Set("max_round", strconv.Itoa(*opts.MaxRound))
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rights []*BakingRight
+	if err := s.Client.Do(req, &rights); err != nil {
+		return nil, err
+	}
+
+	return rights, nil
+}
+
+// GetEndorsingRights returns the endorsing rights of delegates
+// https://tezos.gitlab.io/active/rpc.html#get-block-id-helpers-endorsing-rights
+func (s *Service) GetEndorsingRights(ctx context.Context, chainID, blockID string, opts *EndorsingRightsOptions) ([]*EndorsingRights, error) {
+	u := url.URL{
+		Path: "/chains/" + chainID + "/blocks/" + blockID + "/helpers/endorsing_rights",
+	}
+
+	if opts != nil {
+		q := url.Values{}
+		if opts.Delegate != "" {
+			q.Set("delegate", opts.Delegate)
+		}
+		if opts.Cycle != nil {
+			q.Set("cycle", strconv.Itoa(*opts.Cycle))
+		}
+		if opts.Level != nil {
+			q.Set("level", strconv.Itoa(*opts.Level))
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rights []*EndorsingRights
+	if err := s.Client.Do(req, &rights); err != nil {
+		return nil, err
+	}
+
+	return rights, nil
+}
+
+// GetExpectedIssuance returns the reward amounts expected for the next few cycles
+// https://tezos.gitlab.io/active/rpc.html#get-block-id-context-issuance-expected-issuance
+func (s *Service) GetExpectedIssuance(ctx context.Context, chainID, blockID string) ([]*ExpectedIssuance, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/blocks/"+blockID+"/context/issuance/expected_issuance", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issuance []*ExpectedIssuance
+	if err := s.Client.Do(req, &issuance); err != nil {
+		return nil, err
+	}
+
+	return issuance, nil
+}
+
 // GetBallotList returns ballots casted so far during a voting period.
 // https://tezos.gitlab.io/alphanet/api/rpc.html#get-block-id-votes-ballot-list
 func (s *Service) GetBallotList(ctx context.Context, chainID, blockID string) ([]*Ballot, error) {
@@ -748,6 +1096,144 @@ func (s *Service) GetCurrentPeriodKind(ctx context.Context, chainID, blockID str
 	return periodKind, nil
 }
 
+// GetCurrentPeriod returns the voting period of the block and the block position within it
+// https://tezos.gitlab.io/active/rpc.html#get-block-id-votes-current-period
+func (s *Service) GetCurrentPeriod(ctx context.Context, chainID, blockID string) (*VotingPeriodInfo, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/blocks/"+blockID+"/votes/current_period", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var info VotingPeriodInfo
+	if err := s.Client.Do(req, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// GetChainID returns the hash of the chain, e.g. NetXdQprcVkpaWU for "main" on mainnet.
+// https://tezos.gitlab.io/active/rpc.html#get-chains-chain-id-chain-id
+func (s *Service) GetChainID(ctx context.Context, chainID string) (string, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/chain_id", nil)
+	if err != nil {
+		return "", err
+	}
+
+	var id string
+	if err := s.Client.Do(req, &id); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *Service) getLevel(ctx context.Context, chainID, name string) (*BlockLevel, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/levels/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var level BlockLevel
+	if err := s.Client.Do(req, &level); err != nil {
+		return nil, err
+	}
+
+	return &level, nil
+}
+
+// GetCheckpoint returns the block all the chain's future blocks must descend from, blocks at its level are rejected otherwise.
+// https://tezos.gitlab.io/active/rpc.html#get-chains-chain-id-levels-checkpoint
+func (s *Service) GetCheckpoint(ctx context.Context, chainID string) (*BlockLevel, error) {
+	return s.getLevel(ctx, chainID, "checkpoint")
+}
+
+// GetSavepoint returns the lowest block with metadata, context queries fail for blocks below it.
+// https://tezos.gitlab.io/active/rpc.html#get-chains-chain-id-levels-savepoint
+func (s *Service) GetSavepoint(ctx context.Context, chainID string) (*BlockLevel, error) {
+	return s.getLevel(ctx, chainID, "savepoint")
+}
+
+// GetCaboose returns the lowest block known by the node, it's the genesis block for archive nodes.
+// https://tezos.gitlab.io/active/rpc.html#get-chains-chain-id-levels-caboose
+func (s *Service) GetCaboose(ctx context.Context, chainID string) (*BlockLevel, error) {
+	return s.getLevel(ctx, chainID, "caboose")
+}
+
+// GetLegacyCheckpoint returns the checkpoint along with the savepoint, caboose and history mode levels using
+// the RPC replaced by the levels RPCs in Octez 9.0. Use it for older nodes only.
+func (s *Service) GetLegacyCheckpoint(ctx context.Context, chainID string) (*Checkpoint, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/checkpoint", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint Checkpoint
+	if err := s.Client.Do(req, &checkpoint); err != nil {
+		return nil, err
+	}
+
+	return &checkpoint, nil
+}
+
+func (s *Service) getChainWorkers(ctx context.Context, kind string) ([]*ChainWorker, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/workers/"+kind, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var workers []*ChainWorker
+	if err := s.Client.Do(req, &workers); err != nil {
+		return nil, err
+	}
+
+	return workers, nil
+}
+
+func (s *Service) getWorkerState(ctx context.Context, path string) (*WorkerState, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var state WorkerState
+	if err := s.Client.Do(req, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+// GetPrevalidators returns the status of the mempool prevalidator workers of all chains
+// https://tezos.gitlab.io/active/rpc.html#get-workers-prevalidators
+func (s *Service) GetPrevalidators(ctx context.Context) ([]*ChainWorker, error) {
+	return s.getChainWorkers(ctx, "prevalidators")
+}
+
+// GetPrevalidator returns the state and the request queue of the chain's prevalidator worker
+// https://tezos.gitlab.io/active/rpc.html#get-workers-prevalidators-chain-id
+func (s *Service) GetPrevalidator(ctx context.Context, chainID string) (*WorkerState, error) {
+	return s.getWorkerState(ctx, "/workers/prevalidators/"+chainID)
+}
+
+// GetChainValidators returns the status of the chain validator workers of all chains
+// https://tezos.gitlab.io/active/rpc.html#get-workers-chain-validators
+func (s *Service) GetChainValidators(ctx context.Context) ([]*ChainWorker, error) {
+	return s.getChainWorkers(ctx, "chain_validators")
+}
+
+// GetChainValidator returns the state and the request queue of the chain's validator worker
+// https://tezos.gitlab.io/active/rpc.html#get-workers-chain-validators-chain-id
+func (s *Service) GetChainValidator(ctx context.Context, chainID string) (*WorkerState, error) {
+	return s.getWorkerState(ctx, "/workers/chain_validators/"+chainID)
+}
+
+// GetBlockValidator returns the state and the request queue of the block validator worker
+// https://tezos.gitlab.io/active/rpc.html#get-workers-block-validator
+func (s *Service) GetBlockValidator(ctx context.Context) (*WorkerState, error) {
+	return s.getWorkerState(ctx, "/workers/block_validator")
+}
+
 func (s *Service) GetBootstrapped(ctx context.Context, chainID string) (*BootstrappedStatus, error) {
 	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/chains/"+chainID+"/is_bootstrapped", nil)
 	if err != nil {
@@ -761,3 +1247,51 @@ func (s *Service) GetBootstrapped(ctx context.Context, chainID string) (*Bootstr
 
 	return &status, nil
 }
+
+// GetVersion returns the node's software version, the network version and the commit it was built from.
+// https://tezos.gitlab.io/shell/rpc.html#get-version
+func (s *Service) GetVersion(ctx context.Context) (*VersionInfo, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/version", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var version VersionInfo
+	if err := s.Client.Do(req, &version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// GetGCStats returns the node's garbage collector statistics.
+// https://tezos.gitlab.io/shell/rpc.html#get-stats-gc
+func (s *Service) GetGCStats(ctx context.Context) (*GCStats, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/stats/gc", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats GCStats
+	if err := s.Client.Do(req, &stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// GetMemoryStats returns the node's process memory statistics.
+// https://tezos.gitlab.io/shell/rpc.html#get-stats-memory
+func (s *Service) GetMemoryStats(ctx context.Context) (*MemoryStats, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, "/stats/memory", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats MemoryStats
+	if err := s.Client.Do(req, &stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
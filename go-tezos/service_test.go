@@ -2,6 +2,7 @@ package tezos
 
 import (
 	"context"
+	"encoding/json"
 	"io/ioutil"
 	"math/big"
 	"net/http"
@@ -19,6 +20,31 @@ func timeMustUnmarshalText(text string) (t time.Time) {
 	return
 }
 
+func timeMustUnmarshalTextPtr(text string) *time.Time {
+	t := timeMustUnmarshalText(text)
+	return &t
+}
+
+func bigIntMustParse(text string) *BigInt {
+	var z BigInt
+	if _, ok := z.SetString(text, 10); !ok {
+		panic(text)
+	}
+	return &z
+}
+
+func rawMustUnmarshalFixture(name string) map[string]json.RawMessage {
+	buf, err := ioutil.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		panic(err)
+	}
+	return raw
+}
+
 func TestServiceGetMethods(t *testing.T) {
 	ctx := context.Background()
 	tests := []struct {
@@ -46,6 +72,113 @@ func TestServiceGetMethods(t *testing.T) {
 				CurrentOutflow: 14972,
 			},
 		},
+		{
+			get:             func(s *Service) (interface{}, error) { return s.GetChainID(ctx, "main") },
+			respInline:      `"NetXdQprcVkpaWU"`,
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/chain_id",
+			expectedValue:   "NetXdQprcVkpaWU",
+		},
+		{
+			get:             func(s *Service) (interface{}, error) { return s.GetCheckpoint(ctx, "main") },
+			respInline:      `{"block_hash":"BLc7tKfzia9hnaY1YTMS6RkDniQBoApM4EjKFRLucsuHbiy3eqt","level":223486}`,
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/levels/checkpoint",
+			expectedValue:   &BlockLevel{BlockHash: "BLc7tKfzia9hnaY1YTMS6RkDniQBoApM4EjKFRLucsuHbiy3eqt", Level: 223486},
+		},
+		{
+			get: func(s *Service) (interface{}, error) { return s.GetLegacyCheckpoint(ctx, "main") },
+			respInline: `{"block":{"level":223486,"proto":1,"predecessor":"BLmdoU1U7oDmBZHrAJRBbiukNGD6Nr2xR3W1Dzm2nvSXtWy73Cg","timestamp":"2019-12-04T10:12:46Z",` +
+				`"validation_pass":4,"operations_hash":"LLoZxqdfWYq5RwBLnrDqb8xNfMCVNsDfGUz4hbfhhSwqAjuUAuXGB","fitness":["01","000000000003690e"],` +
+				`"context":"CoVvjd6RxfWVNAn1fdCnvbAZwm1SHkQBVKGeHEGD3DXvc5R6zkaY","protocol_data":"0000"},"save_point":219133,"caboose":0,"history_mode":"full"}`,
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/checkpoint",
+			expectedValue: &Checkpoint{
+				Block: BlockInfo{
+					Level:          223486,
+					Proto:          1,
+					Predecessor:    "BLmdoU1U7oDmBZHrAJRBbiukNGD6Nr2xR3W1Dzm2nvSXtWy73Cg",
+					Timestamp:      time.Date(2019, 12, 4, 10, 12, 46, 0, time.UTC),
+					ValidationPass: 4,
+					OperationsHash: "LLoZxqdfWYq5RwBLnrDqb8xNfMCVNsDfGUz4hbfhhSwqAjuUAuXGB",
+					Fitness:        []HexBytes{{0x01}, {0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x69, 0x0e}},
+					Context:        "CoVvjd6RxfWVNAn1fdCnvbAZwm1SHkQBVKGeHEGD3DXvc5R6zkaY",
+					ProtocolData:   "0000",
+				},
+				SavePoint:   219133,
+				Caboose:     0,
+				HistoryMode: HistoryModeFull,
+			},
+		},
+		{
+			get:             func(s *Service) (interface{}, error) { return s.GetSavepoint(ctx, "main") },
+			respInline:      `{"block_hash":"BLnoArJNPCyYFK2z3Mnomi36Jo3FwrjriJ6hvzgTJGYYDKEkDXm","level":219133}`,
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/levels/savepoint",
+			expectedValue:   &BlockLevel{BlockHash: "BLnoArJNPCyYFK2z3Mnomi36Jo3FwrjriJ6hvzgTJGYYDKEkDXm", Level: 219133},
+		},
+		{
+			get:             func(s *Service) (interface{}, error) { return s.GetCaboose(ctx, "main") },
+			respInline:      `{"block_hash":"BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW","level":0}`,
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/levels/caboose",
+			expectedValue:   &BlockLevel{BlockHash: "BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW", Level: 0},
+		},
+		{
+			get: func(s *Service) (interface{}, error) { return s.GetPrevalidators(ctx) },
+			respInline: `[{"chain_id":"NetXdQprcVkpaWU","status":{"phase":"running","since":"2024-07-22T08:00:12Z"},"information":{"instances_number":1},"pipelines":0},` +
+				`{"chain_id":"NetXfEXqFxvGjZs","status":{"phase":"closed","birth":"2024-07-22T08:00:12Z","since":"2024-07-22T09:00:00Z","errors":[{"kind":"temporary","id":"node.prevalidator.closed"}]}}]`,
+			respContentType: "application/json",
+			expectedPath:    "/workers/prevalidators",
+			expectedValue: []*ChainWorker{
+				{ChainID: "NetXdQprcVkpaWU", Status: WorkerStatus{Phase: WorkerRunning, Since: timeMustUnmarshalText("2024-07-22T08:00:12Z")}, Information: json.RawMessage(`{"instances_number":1}`)},
+				{ChainID: "NetXfEXqFxvGjZs", Status: WorkerStatus{Phase: WorkerClosed, Since: timeMustUnmarshalText("2024-07-22T09:00:00Z"), Birth: timeMustUnmarshalTextPtr("2024-07-22T08:00:12Z"), Errors: Errors{&GenericError{Kind: "temporary", ID: "node.prevalidator.closed"}}}},
+			},
+		},
+		{
+			get:             func(s *Service) (interface{}, error) { return s.GetChainValidators(ctx) },
+			respInline:      `[{"chain_id":"NetXdQprcVkpaWU","status":{"phase":"running","since":"2024-07-22T08:00:12Z"}}]`,
+			respContentType: "application/json",
+			expectedPath:    "/workers/chain_validators",
+			expectedValue: []*ChainWorker{
+				{ChainID: "NetXdQprcVkpaWU", Status: WorkerStatus{Phase: WorkerRunning, Since: timeMustUnmarshalText("2024-07-22T08:00:12Z")}},
+			},
+		},
+		{
+			get: func(s *Service) (interface{}, error) { return s.GetBlockValidator(ctx) },
+			respInline: `{"status":{"phase":"running","since":"2024-07-22T08:00:12Z"},"pending_requests":[{"pushed":"2024-07-22T09:12:52Z","request":{"block":"BM8i6rp9HYngV1sdFMT1BNQgGa4NtA7bj6r9hRu5iyzR7AsPPDn"}}],` +
+				`"backlog":[],"current_request":{"pushed":"2024-07-22T09:12:44Z","treated":"2024-07-22T09:12:45Z","request":{"block":"BLrrfnbYgCt1XnZGmhEg8pygAWhDQB1dj2Q6ogzx6yS5fnvbsWP"}}}`,
+			respContentType: "application/json",
+			expectedPath:    "/workers/block_validator",
+			expectedValue: &WorkerState{
+				Status: WorkerStatus{Phase: WorkerRunning, Since: timeMustUnmarshalText("2024-07-22T08:00:12Z")},
+				PendingRequests: []*WorkerRequest{
+					{Pushed: timeMustUnmarshalText("2024-07-22T09:12:52Z"), Request: json.RawMessage(`{"block":"BM8i6rp9HYngV1sdFMT1BNQgGa4NtA7bj6r9hRu5iyzR7AsPPDn"}`)},
+				},
+				CurrentRequest: &WorkerRequest{Pushed: timeMustUnmarshalText("2024-07-22T09:12:44Z"), Treated: timeMustUnmarshalTextPtr("2024-07-22T09:12:45Z"), Request: json.RawMessage(`{"block":"BLrrfnbYgCt1XnZGmhEg8pygAWhDQB1dj2Q6ogzx6yS5fnvbsWP"}`)},
+			},
+		},
+		{
+			get:             func(s *Service) (interface{}, error) { return s.GetPrevalidator(ctx, "main") },
+			respInline:      `{"status":{"phase":"running","since":"2024-07-22T08:00:12Z"},"pending_requests":[],"backlog":[]}`,
+			respContentType: "application/json",
+			expectedPath:    "/workers/prevalidators/main",
+			expectedValue:   &WorkerState{Status: WorkerStatus{Phase: WorkerRunning, Since: timeMustUnmarshalText("2024-07-22T08:00:12Z")}, PendingRequests: []*WorkerRequest{}},
+		},
+		{
+			get:             func(s *Service) (interface{}, error) { return s.GetChainValidator(ctx, "main") },
+			respInline:      `{"status":{"phase":"running","since":"2024-07-22T08:00:12Z"},"pending_requests":[],"backlog":[]}`,
+			respContentType: "application/json",
+			expectedPath:    "/workers/chain_validators/main",
+			expectedValue:   &WorkerState{Status: WorkerStatus{Phase: WorkerRunning, Since: timeMustUnmarshalText("2024-07-22T08:00:12Z")}, PendingRequests: []*WorkerRequest{}},
+		},
+		{
+			get:             func(s *Service) (interface{}, error) { return s.GetNetworkSelf(ctx) },
+			respInline:      `"idt5qvkLiJ15rb6yJU1bjpGmdyYnPJ"`,
+			respContentType: "application/json",
+			expectedPath:    "/network/self",
+			expectedValue:   "idt5qvkLiJ15rb6yJU1bjpGmdyYnPJ",
+		},
 		{
 			get:             func(s *Service) (interface{}, error) { return s.GetNetworkConnections(ctx) },
 			respFixture:     "fixtures/network/connections.json",
@@ -53,6 +186,13 @@ func TestServiceGetMethods(t *testing.T) {
 			expectedPath:    "/network/connections",
 			expectedValue:   []*NetworkConnection{{Incoming: false, PeerID: "idt5qvkLiJ15rb6yJU1bjpGmdyYnPJ", IDPoint: NetworkAddress{Addr: "::ffff:34.253.64.43", Port: 0x2604}, RemoteSocketPort: 0x2604, Versions: []*NetworkVersion{{Name: "TEZOS_ALPHANET_2018-07-31T16:22:39Z", Major: 0x0, Minor: 0x0}}, Private: false, LocalMetadata: NetworkMetadata{DisableMempool: false, PrivateNode: false}, RemoteMetadata: NetworkMetadata{DisableMempool: false, PrivateNode: false}}, {Incoming: true, PeerID: "ids8VJTHEuyND6B8ahGgXPAJ3BDp1c", IDPoint: NetworkAddress{Addr: "::ffff:176.31.255.202", Port: 0x2604}, RemoteSocketPort: 0x2604, Versions: []*NetworkVersion{{Name: "TEZOS_ALPHANET_2018-07-31T16:22:39Z", Major: 0x0, Minor: 0x0}}, Private: true, LocalMetadata: NetworkMetadata{DisableMempool: true, PrivateNode: true}, RemoteMetadata: NetworkMetadata{DisableMempool: true, PrivateNode: true}}},
 		},
+		{
+			get:             func(s *Service) (interface{}, error) { return s.GetNetworkConnections(ctx) },
+			respInline:      `[{"incoming":true,"peer_id":"idt5qvkLiJ15rb6yJU1bjpGmdyYnPJ","id_point":{"addr":"::ffff:34.253.64.43","port":9732},"remote_socket_port":9732,"announced_version":{"chain_name":"TEZOS_MAINNET","distributed_db_version":2,"p2p_version":1},"private":false,"local_metadata":{"disable_mempool":false,"private_node":false},"remote_metadata":{"disable_mempool":false,"private_node":false}}]`,
+			respContentType: "application/json",
+			expectedPath:    "/network/connections",
+			expectedValue:   []*NetworkConnection{{Incoming: true, PeerID: "idt5qvkLiJ15rb6yJU1bjpGmdyYnPJ", IDPoint: NetworkAddress{Addr: "::ffff:34.253.64.43", Port: 0x2604}, RemoteSocketPort: 0x2604, AnnouncedVersion: &NetworkAnnouncedVersion{ChainName: "TEZOS_MAINNET", DistributedDBVersion: 2, P2PVersion: 1}}},
+		},
 		{
 			get:             func(s *Service) (interface{}, error) { return s.GetNetworkPeers(ctx, "") },
 			respFixture:     "fixtures/network/peers.json",
@@ -166,6 +306,31 @@ func TestServiceGetMethods(t *testing.T) {
 			expectedMethod:  "PUT",
 			expectedQuery:   "timeout=10.000000",
 		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return nil, s.BanNetworkPeer(ctx, "idtTZmNapGXAcfbnPoAcDz6J2xCHZZ")
+			},
+			respInline:      "{}",
+			respContentType: "application/json",
+			expectedPath:    "/network/peers/idtTZmNapGXAcfbnPoAcDz6J2xCHZZ/ban",
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return nil, s.ClearNetworkGreylist(ctx)
+			},
+			respInline:      "{}",
+			respContentType: "application/json",
+			expectedPath:    "/network/greylist",
+			expectedMethod:  "DELETE",
+		},
+		{
+			get:             func(s *Service) (interface{}, error) { return s.GetDelegates(ctx, "main", "head", true) },
+			respInline:      `["tz3WXYtyDUNL91qfiCJtVUX746QpNv5i5ve5","tz1VWasoyFGAWZt5K2qZRzP3cWzv3z7MMhP8"]`,
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/context/delegates",
+			expectedQuery:   "active",
+			expectedValue:   []string{"tz3WXYtyDUNL91qfiCJtVUX746QpNv5i5ve5", "tz1VWasoyFGAWZt5K2qZRzP3cWzv3z7MMhP8"},
+		},
 		{
 			get: func(s *Service) (interface{}, error) {
 				return s.GetDelegateBalance(ctx, "main", "head", "tz3WXYtyDUNL91qfiCJtVUX746QpNv5i5ve5")
@@ -175,6 +340,24 @@ func TestServiceGetMethods(t *testing.T) {
 			expectedPath:    "/chains/main/blocks/head/context/delegates/tz3WXYtyDUNL91qfiCJtVUX746QpNv5i5ve5/balance",
 			expectedValue:   big.NewInt(13490453135591),
 		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetDelegateStakingBalance(ctx, "main", "head", "tz3WXYtyDUNL91qfiCJtVUX746QpNv5i5ve5")
+			},
+			respInline:      `"27125924452118"`,
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/context/delegates/tz3WXYtyDUNL91qfiCJtVUX746QpNv5i5ve5/staking_balance",
+			expectedValue:   big.NewInt(27125924452118),
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetDelegateDelegatedBalance(ctx, "main", "head", "tz3WXYtyDUNL91qfiCJtVUX746QpNv5i5ve5")
+			},
+			respInline:      `"13635471316527"`,
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/context/delegates/tz3WXYtyDUNL91qfiCJtVUX746QpNv5i5ve5/delegated_balance",
+			expectedValue:   big.NewInt(13635471316527),
+		},
 		{
 			get: func(s *Service) (interface{}, error) {
 				return s.GetContractBalance(ctx, "main", "head", "tz3WXYtyDUNL91qfiCJtVUX746QpNv5i5ve5")
@@ -207,6 +390,29 @@ func TestServiceGetMethods(t *testing.T) {
 				{Block: "BKiqiXgqAPHX4bRzk2p1jEKHijaxLPdcQi8hqVfGhBwngcticEk", Timestamp: timeMustUnmarshalText("2018-09-17T00:48:32Z")},
 			},
 		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				ch := make(chan *BootstrappedBlock, 100)
+				if err := s.MonitorBootstrapped(ctx, ch); err != nil {
+					return nil, err
+				}
+				close(ch)
+
+				var res []*BootstrappedBlock
+				for b := range ch {
+					res = append(res, b)
+				}
+				return res, nil
+			},
+			respFixture:     "fixtures/monitor/bootstrapped.sse",
+			respContentType: "text/event-stream",
+			expectedPath:    "/monitor/bootstrapped",
+			expectedValue: []*BootstrappedBlock{
+				{Block: "BLgz6z8w5bYtn2AAEmsfMD3aH9o8SUnVygUpVUsCe6dkRpEt5Qy", Timestamp: timeMustUnmarshalText("2018-09-17T00:46:12Z")},
+				{Block: "BLc3Y6zsb7PT6QnScu8VKcUPGkCoeCLPWLVTQoQjk5QQ7pbmHs5", Timestamp: timeMustUnmarshalText("2018-09-17T00:46:42Z")},
+				{Block: "BKiqiXgqAPHX4bRzk2p1jEKHijaxLPdcQi8hqVfGhBwngcticEk", Timestamp: timeMustUnmarshalText("2018-09-17T00:48:32Z")},
+			},
+		},
 		{
 			get: func(s *Service) (interface{}, error) {
 				return s.GetMempoolPendingOperations(ctx, "main")
@@ -289,6 +495,114 @@ func TestServiceGetMethods(t *testing.T) {
 			expectedPath:    "/chains/main/blocks/BLnoArJNPCyYFK2z3Mnomi36Jo3FwrjriJ6hvzgTJGYYDKEkDXm",
 			expectedValue:   &Block{Protocol: "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt", ChainID: "NetXZUqeBjDnWde", Hash: "BLnoArJNPCyYFK2z3Mnomi36Jo3FwrjriJ6hvzgTJGYYDKEkDXm", Header: RawBlockHeader{Level: 219133, Proto: 1, Predecessor: "BLNWdEensT9MFq8pkDwjHfGVFsV1reYUhVcMAVzq3LCMS1WdKZ8", Timestamp: timeMustUnmarshalText("2018-11-27T17:49:57Z"), ValidationPass: 4, OperationsHash: "LLoZamNeucV8tqPAcqJQYsNEsMwnCuL1xu1kJMiGFCx9MBVCGcWJF", Fitness: []HexBytes{{0x0}, {0x0, 0x0, 0x0, 0x0, 0x0, 0x5a, 0x12, 0x5f}}, Context: "CoW5zHjWVHfUAbSgzqnZ938eDXG37P9oJVn3Lb3NyQJBheUDvdVf", ProofOfWorkNonce: HexBytes{0x7d, 0x94, 0x95, 0x82, 0xfe, 0x2, 0x48, 0x62}, Signature: "sigktdiZpdykWEjgeTB3N1qFJ5bsh3SxVNB8wc5FAutbJPG7puWQAPrxwL6BZPJVKLRj2uLnCw54Akx4KA48DS5Jg8tthCLY"}, Metadata: BlockHeaderMetadata{Protocol: "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt", NextProtocol: "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt", TestChainStatus: &NotRunningTestChainStatus{GenericTestChainStatus: GenericTestChainStatus{Status: "not_running"}}, MaxOperationsTTL: 60, MaxOperationDataLength: 16384, MaxBlockHeaderLength: 238, MaxOperationListLength: []*MaxOperationListLength{{MaxSize: 32768, MaxOp: 32}}, Baker: "tz3gN8NTLNLJg5KRsUU47NHNVHbdhcFXjjaB", Level: BlockHeaderMetadataLevel{Level: 219133, LevelPosition: 219132, Cycle: 106, CyclePosition: 2044, VotingPeriod: 6, VotingPeriodPosition: 22524, ExpectedCommitment: false}, VotingPeriodKind: "proposal", ConsumedGas: &BigInt{}, Deactivated: []string{}, BalanceUpdates: BalanceUpdates{&ContractBalanceUpdate{GenericBalanceUpdate: GenericBalanceUpdate{Kind: "contract", Change: -512000000}, Contract: "tz3gN8NTLNLJg5KRsUU47NHNVHbdhcFXjjaB"}, &FreezerBalanceUpdate{GenericBalanceUpdate: GenericBalanceUpdate{Kind: "freezer", Change: 512000000}, Category: "deposits", Delegate: "tz3gN8NTLNLJg5KRsUU47NHNVHbdhcFXjjaB", Level: 106}}}, Operations: [][]*Operation{{&Operation{Protocol: "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt", ChainID: "NetXZUqeBjDnWde", Hash: "opEatwYFvwuUM2aEa9cUU1ofMzsi46bYwiUhPLENXpLkjpps4Xq", Branch: "BLNWdEensT9MFq8pkDwjHfGVFsV1reYUhVcMAVzq3LCMS1WdKZ8", Contents: OperationElements{&EndorsementOperationElem{GenericOperationElem: GenericOperationElem{Kind: "endorsement"}, Level: 219132, Metadata: EndorsementOperationMetadata{BalanceUpdates: BalanceUpdates{&ContractBalanceUpdate{GenericBalanceUpdate: GenericBalanceUpdate{Kind: "contract", Change: -128000000}, Contract: "tz1SfH1vxAt2TTZV7mpsN79uGas5LHhV8epq"}, &FreezerBalanceUpdate{GenericBalanceUpdate: GenericBalanceUpdate{Kind: "freezer", Change: 128000000}, Category: "deposits", Delegate: "tz1SfH1vxAt2TTZV7mpsN79uGas5LHhV8epq", Level: 106}, &FreezerBalanceUpdate{GenericBalanceUpdate: GenericBalanceUpdate{Kind: "freezer", Change: 2000000}, Category: "rewards", Delegate: "tz1SfH1vxAt2TTZV7mpsN79uGas5LHhV8epq", Level: 106}}, Delegate: "tz1SfH1vxAt2TTZV7mpsN79uGas5LHhV8epq", Slots: []int{18, 16}}}}, Signature: "sigS3d9wfEFuChEqLetCxf4G8QYAjWL7ND3F8amMPVPDS2RwQqkeKU9hbrEXk7GG7U2aPcWkTA3uTdNzz4gkAb8jSy8hUc51"}}, {}, {}, {}}},
 		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetBlockWithOptions(ctx, "main", "BLnoArJNPCyYFK2z3Mnomi36Jo3FwrjriJ6hvzgTJGYYDKEkDXm", &BlockOptions{Metadata: MetadataAlways, ForceMetadata: true})
+			},
+			respFixture:     "fixtures/chains/block.json",
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/BLnoArJNPCyYFK2z3Mnomi36Jo3FwrjriJ6hvzgTJGYYDKEkDXm",
+			expectedQuery:   "force_metadata=true&metadata=always",
+			expectedValue:   &Block{Protocol: "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt", ChainID: "NetXZUqeBjDnWde", Hash: "BLnoArJNPCyYFK2z3Mnomi36Jo3FwrjriJ6hvzgTJGYYDKEkDXm", Header: RawBlockHeader{Level: 219133, Proto: 1, Predecessor: "BLNWdEensT9MFq8pkDwjHfGVFsV1reYUhVcMAVzq3LCMS1WdKZ8", Timestamp: timeMustUnmarshalText("2018-11-27T17:49:57Z"), ValidationPass: 4, OperationsHash: "LLoZamNeucV8tqPAcqJQYsNEsMwnCuL1xu1kJMiGFCx9MBVCGcWJF", Fitness: []HexBytes{{0x0}, {0x0, 0x0, 0x0, 0x0, 0x0, 0x5a, 0x12, 0x5f}}, Context: "CoW5zHjWVHfUAbSgzqnZ938eDXG37P9oJVn3Lb3NyQJBheUDvdVf", ProofOfWorkNonce: HexBytes{0x7d, 0x94, 0x95, 0x82, 0xfe, 0x2, 0x48, 0x62}, Signature: "sigktdiZpdykWEjgeTB3N1qFJ5bsh3SxVNB8wc5FAutbJPG7puWQAPrxwL6BZPJVKLRj2uLnCw54Akx4KA48DS5Jg8tthCLY"}, Metadata: BlockHeaderMetadata{Protocol: "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt", NextProtocol: "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt", TestChainStatus: &NotRunningTestChainStatus{GenericTestChainStatus: GenericTestChainStatus{Status: "not_running"}}, MaxOperationsTTL: 60, MaxOperationDataLength: 16384, MaxBlockHeaderLength: 238, MaxOperationListLength: []*MaxOperationListLength{{MaxSize: 32768, MaxOp: 32}}, Baker: "tz3gN8NTLNLJg5KRsUU47NHNVHbdhcFXjjaB", Level: BlockHeaderMetadataLevel{Level: 219133, LevelPosition: 219132, Cycle: 106, CyclePosition: 2044, VotingPeriod: 6, VotingPeriodPosition: 22524, ExpectedCommitment: false}, VotingPeriodKind: "proposal", ConsumedGas: &BigInt{}, Deactivated: []string{}, BalanceUpdates: BalanceUpdates{&ContractBalanceUpdate{GenericBalanceUpdate: GenericBalanceUpdate{Kind: "contract", Change: -512000000}, Contract: "tz3gN8NTLNLJg5KRsUU47NHNVHbdhcFXjjaB"}, &FreezerBalanceUpdate{GenericBalanceUpdate: GenericBalanceUpdate{Kind: "freezer", Change: 512000000}, Category: "deposits", Delegate: "tz3gN8NTLNLJg5KRsUU47NHNVHbdhcFXjjaB", Level: 106}}}, Operations: [][]*Operation{{&Operation{Protocol: "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt", ChainID: "NetXZUqeBjDnWde", Hash: "opEatwYFvwuUM2aEa9cUU1ofMzsi46bYwiUhPLENXpLkjpps4Xq", Branch: "BLNWdEensT9MFq8pkDwjHfGVFsV1reYUhVcMAVzq3LCMS1WdKZ8", Contents: OperationElements{&EndorsementOperationElem{GenericOperationElem: GenericOperationElem{Kind: "endorsement"}, Level: 219132, Metadata: EndorsementOperationMetadata{BalanceUpdates: BalanceUpdates{&ContractBalanceUpdate{GenericBalanceUpdate: GenericBalanceUpdate{Kind: "contract", Change: -128000000}, Contract: "tz1SfH1vxAt2TTZV7mpsN79uGas5LHhV8epq"}, &FreezerBalanceUpdate{GenericBalanceUpdate: GenericBalanceUpdate{Kind: "freezer", Change: 128000000}, Category: "deposits", Delegate: "tz1SfH1vxAt2TTZV7mpsN79uGas5LHhV8epq", Level: 106}, &FreezerBalanceUpdate{GenericBalanceUpdate: GenericBalanceUpdate{Kind: "freezer", Change: 2000000}, Category: "rewards", Delegate: "tz1SfH1vxAt2TTZV7mpsN79uGas5LHhV8epq", Level: 106}}, Delegate: "tz1SfH1vxAt2TTZV7mpsN79uGas5LHhV8epq", Slots: []int{18, 16}}}}, Signature: "sigS3d9wfEFuChEqLetCxf4G8QYAjWL7ND3F8amMPVPDS2RwQqkeKU9hbrEXk7GG7U2aPcWkTA3uTdNzz4gkAb8jSy8hUc51"}}, {}, {}, {}}},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetBlockHeader(ctx, "main", "head")
+			},
+			respFixture:     "fixtures/chains/header.json",
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/header",
+			expectedValue:   &BlockHeader{Protocol: "PsYLVpVvgbLhAhoqAkMFUo6gudkJ9weNXhUYCiLDzcUpFpkk8Wt", ChainID: "NetXZUqeBjDnWde", Hash: "BLnoArJNPCyYFK2z3Mnomi36Jo3FwrjriJ6hvzgTJGYYDKEkDXm", RawBlockHeader: RawBlockHeader{Level: 219133, Proto: 1, Predecessor: "BLNWdEensT9MFq8pkDwjHfGVFsV1reYUhVcMAVzq3LCMS1WdKZ8", Timestamp: timeMustUnmarshalText("2018-11-27T17:49:57Z"), ValidationPass: 4, OperationsHash: "LLoZamNeucV8tqPAcqJQYsNEsMwnCuL1xu1kJMiGFCx9MBVCGcWJF", Fitness: []HexBytes{{0x0}, {0x0, 0x0, 0x0, 0x0, 0x0, 0x5a, 0x12, 0x5f}}, Context: "CoW5zHjWVHfUAbSgzqnZ938eDXG37P9oJVn3Lb3NyQJBheUDvdVf", ProofOfWorkNonce: HexBytes{0x7d, 0x94, 0x95, 0x82, 0xfe, 0x2, 0x48, 0x62}, Signature: "sigktdiZpdykWEjgeTB3N1qFJ5bsh3SxVNB8wc5FAutbJPG7puWQAPrxwL6BZPJVKLRj2uLnCw54Akx4KA48DS5Jg8tthCLY"}},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetConstants(ctx, "main", "head")
+			},
+			respFixture:     "fixtures/chains/constants.json",
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/context/constants",
+			expectedValue:   &Constants{BlocksPerCycle: 30720, ConsensusCommitteeSize: 7000, ConsensusThreshold: 4667, MinimalBlockDelay: 8, HardGasLimitPerOperation: 1040000, HardGasLimitPerBlock: 1386666, HardStorageLimitPerOperation: 60000, Raw: rawMustUnmarshalFixture("fixtures/chains/constants.json")},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				cycle, round := 745, 0
+				return s.GetBakingRights(ctx, "main", "head", &BakingRightsOptions{Delegate: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9", Cycle: &cycle, MaxRound: &round})
+			},
+			respFixture:     "fixtures/block/baking_rights.json",
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/helpers/baking_rights",
+			expectedQuery:   "cycle=745&delegate=tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9&max_round=0",
+			expectedValue: []*BakingRight{
+				{Level: 5726209, Delegate: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9", Round: 0, EstimatedTime: timeMustUnmarshalTextPtr("2024-07-22T09:12:44Z"), ConsensusKey: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9"},
+				{Level: 5726312, Delegate: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9", Round: 0, EstimatedTime: timeMustUnmarshalTextPtr("2024-07-22T09:26:28Z"), ConsensusKey: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9"},
+			},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				level := 5726209
+				round := 2
+				return s.GetBakingRights(ctx, "main", "head", &BakingRightsOptions{Level: &level, MaxRound: &round})
+			},
+			respInline:      `[{"level":5726209,"delegate":"tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9","round":0,"estimated_time":"2024-07-22T09:12:44Z","consensus_key":"tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9"},{"level":5726209,"delegate":"tz1VWasoyFGAWZt5K2qZRzP3cWzv3z7MMhP8","round":1,"estimated_time":"2024-07-22T09:13:00Z","consensus_key":"tz1VWasoyFGAWZt5K2qZRzP3cWzv3z7MMhP8"}]`,
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/helpers/baking_rights",
+			expectedQuery:   "level=5726209&max_round=2",
+			expectedValue: []*BakingRight{
+				{Level: 5726209, Delegate: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9", Round: 0, EstimatedTime: timeMustUnmarshalTextPtr("2024-07-22T09:12:44Z"), ConsensusKey: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9"},
+				{Level: 5726209, Delegate: "tz1VWasoyFGAWZt5K2qZRzP3cWzv3z7MMhP8", Round: 1, EstimatedTime: timeMustUnmarshalTextPtr("2024-07-22T09:13:00Z"), ConsensusKey: "tz1VWasoyFGAWZt5K2qZRzP3cWzv3z7MMhP8"},
+			},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				cycle := 745
+				return s.GetEndorsingRights(ctx, "main", "head", &EndorsingRightsOptions{Delegate: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9", Cycle: &cycle})
+			},
+			respFixture:     "fixtures/block/endorsing_rights.json",
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/helpers/endorsing_rights",
+			expectedQuery:   "cycle=745&delegate=tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9",
+			expectedValue: []*EndorsingRights{
+				{Level: 5726209, Delegates: []*DelegateEndorsingRight{{Delegate: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9", FirstSlot: 11, AttestationPower: 171, ConsensusKey: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9"}}, EstimatedTime: timeMustUnmarshalTextPtr("2024-07-22T09:12:44Z")},
+				{Level: 5726210, Delegates: []*DelegateEndorsingRight{{Delegate: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9", FirstSlot: 3, AttestationPower: 165, ConsensusKey: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9"}}, EstimatedTime: timeMustUnmarshalTextPtr("2024-07-22T09:12:52Z")},
+			},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				level := 5726209
+				return s.GetEndorsingRights(ctx, "main", "head", &EndorsingRightsOptions{Level: &level})
+			},
+			respInline:      `[{"level":5726209,"delegates":[{"delegate":"tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9","first_slot":11,"attestation_power":171,"consensus_key":"tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9"}]}]`,
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/helpers/endorsing_rights",
+			expectedQuery:   "level=5726209",
+			expectedValue: []*EndorsingRights{
+				{Level: 5726209, Delegates: []*DelegateEndorsingRight{{Delegate: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9", FirstSlot: 11, AttestationPower: 171, ConsensusKey: "tz3RDC3Jdn4j15J7bBHZd29EUee9gVB1CxD9"}}},
+			},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetEndorsingRights(ctx, "main", "head", &EndorsingRightsOptions{Delegate: "tz1VWasoyFGAWZt5K2qZRzP3cWzv3z7MMhP8"})
+			},
+			respInline:      `[{"level":1400001,"delegate":"tz1VWasoyFGAWZt5K2qZRzP3cWzv3z7MMhP8","slots":[17,3,29],"estimated_time":"2021-03-29T10:12:44Z"}]`,
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/helpers/endorsing_rights",
+			expectedQuery:   "delegate=tz1VWasoyFGAWZt5K2qZRzP3cWzv3z7MMhP8",
+			expectedValue: []*EndorsingRights{
+				{Level: 1400001, Delegates: []*DelegateEndorsingRight{{Delegate: "tz1VWasoyFGAWZt5K2qZRzP3cWzv3z7MMhP8", FirstSlot: 3, EndorsingPower: 3}}, EstimatedTime: timeMustUnmarshalTextPtr("2021-03-29T10:12:44Z")},
+			},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetExpectedIssuance(ctx, "main", "head")
+			},
+			respFixture:     "fixtures/chains/expected_issuance.json",
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/context/issuance/expected_issuance",
+			expectedValue: []*ExpectedIssuance{
+				{Cycle: 745, BakingRewardFixedPortion: bigIntMustParse("9563025"), BakingRewardBonusPerSlot: bigIntMustParse("4099"), AttestingRewardPerSlot: bigIntMustParse("2732"), SeedNonceRevelationTip: bigIntMustParse("1867"), VDFRevelationTip: bigIntMustParse("1867")},
+				{Cycle: 746, BakingRewardFixedPortion: bigIntMustParse("9562880"), BakingRewardBonusPerSlot: bigIntMustParse("4098"), AttestingRewardPerSlot: bigIntMustParse("2732"), SeedNonceRevelationTip: bigIntMustParse("1867"), VDFRevelationTip: bigIntMustParse("1867")},
+			},
+		},
 		{
 			get: func(s *Service) (interface{}, error) {
 				ch := make(chan *BlockInfo, 100)
@@ -310,6 +624,71 @@ func TestServiceGetMethods(t *testing.T) {
 				{Hash: "BKq199p1Hm1phfJ4DhuRjB6yBSJnDNG8sgMSnja9pXR96T2Hyy1", Timestamp: timeMustUnmarshalText("2019-04-10T22:37:08Z"), OperationsHash: "LLobC6LA4T2STTa3D77YDuDsrw6xEY8DakpkvR9kd7DL9HpvchUtb", Level: 390397, Context: "CoUiJrzomxKms5eELzgpULo2iyf7dJAqW3gEBnFE7WHv3cy9pfVE", Predecessor: "BKihh4Bd3nAypX5bZtYy7xoxQDRbygkoyjB9w171exm2mbXHQWj", Proto: 3, ProtocolData: "000000000003bcf5f72d00320dffeb51c154077ce7dd2af6057f0370485a738345d3cb5c722db6df6ddb9b48c4e7a4282a3b994bca1cc52f6b95c889f23906e1d4e3e20203e171ff924004", ValidationPass: 4, Fitness: []HexBytes{{0x0}, {0x0, 0x0, 0x0, 0x0, 0x0, 0x5a, 0x12, 0x5f}}},
 			},
 		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				ch := make(chan string, 100)
+				if err := s.MonitorProtocols(ctx, ch); err != nil {
+					return nil, err
+				}
+				close(ch)
+
+				var res []string
+				for p := range ch {
+					res = append(res, p)
+				}
+				return res, nil
+			},
+			respFixture:     "fixtures/monitor/protocols.chunked",
+			respContentType: "application/json",
+			expectedPath:    "/monitor/protocols",
+			expectedValue:   []string{"PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ", "PsParisCZo7KAh1Z1smVd9ZMZ1HHn5gkzbM94V3PLCpknFWhUAi"},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				ch := make(chan []*ActiveChain, 100)
+				if err := s.MonitorActiveChains(ctx, ch); err != nil {
+					return nil, err
+				}
+				close(ch)
+
+				var res [][]*ActiveChain
+				for c := range ch {
+					res = append(res, c)
+				}
+				return res, nil
+			},
+			respFixture:     "fixtures/monitor/active_chains.chunked",
+			respContentType: "application/json",
+			expectedPath:    "/monitor/active_chains",
+			expectedValue: [][]*ActiveChain{
+				{{ChainID: "NetXdQprcVkpaWU"}},
+				{{ChainID: "NetXdQprcVkpaWU"}, {ChainID: "NetXfEXqFxvGjZs", TestProtocol: "PsParisCZo7KAh1Z1smVd9ZMZ1HHn5gkzbM94V3PLCpknFWhUAi", ExpirationDate: timeMustUnmarshalTextPtr("2024-07-24T09:12:44Z")}},
+				{{ChainID: "NetXdQprcVkpaWU"}, {Stopping: "NetXfEXqFxvGjZs"}},
+			},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				ch := make(chan *ValidBlock, 100)
+				if err := s.MonitorValidBlocks(ctx, "main", "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ", ch); err != nil {
+					return nil, err
+				}
+				close(ch)
+
+				var res []*ValidBlock
+				for b := range ch {
+					res = append(res, b)
+				}
+				return res, nil
+			},
+			respFixture:     "fixtures/monitor/valid_blocks.chunked",
+			respContentType: "application/json",
+			expectedPath:    "/monitor/valid_blocks",
+			expectedQuery:   "chains=main&protocol=PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ",
+			expectedValue: []*ValidBlock{
+				{ChainID: "NetXdQprcVkpaWU", BlockInfo: BlockInfo{Hash: "BLrrfnbYgCt1XnZGmhEg8pygAWhDQB1dj2Q6ogzx6yS5fnvbsWP", Level: 5726209, Proto: 19, Predecessor: "BLWdgKnrQArR1RQDDpdmSm2RLKDcNLHdy6mDvn6mdHYnNGXUCEi", Timestamp: timeMustUnmarshalText("2024-07-22T09:12:44Z"), ValidationPass: 4, OperationsHash: "LLoa4Nq8yvtC9vGBfEjnZeHZF8MXLjjAjV4kkfBN4nsNRBnWm9Tzs", Fitness: []HexBytes{{0x02}, {0x00, 0x57, 0x60, 0x01}, {}, {0xff, 0xff, 0xff, 0xff}, {0x00, 0x00, 0x00, 0x00}}, Context: "CoVDrPH2FeWwKyG1XBNsiu6Yy7rWzZgTgczGkqTqpbPhVhqrPjMJ"}},
+				{ChainID: "NetXdQprcVkpaWU", BlockInfo: BlockInfo{Hash: "BM8i6rp9HYngV1sdFMT1BNQgGa4NtA7bj6r9hRu5iyzR7AsPPDn", Level: 5726209, Proto: 19, Predecessor: "BLWdgKnrQArR1RQDDpdmSm2RLKDcNLHdy6mDvn6mdHYnNGXUCEi", Timestamp: timeMustUnmarshalText("2024-07-22T09:12:52Z"), ValidationPass: 4, OperationsHash: "LLoa4Nq8yvtC9vGBfEjnZeHZF8MXLjjAjV4kkfBN4nsNRBnWm9Tzs", Fitness: []HexBytes{{0x02}, {0x00, 0x57, 0x60, 0x01}, {}, {0xff, 0xff, 0xff, 0xfe}, {0x00, 0x00, 0x00, 0x01}}, Context: "CoVDrPH2FeWwKyG1XBNsiu6Yy7rWzZgTgczGkqTqpbPhVhqrPjMJ"}},
+			},
+		},
 		{
 			get: func(s *Service) (interface{}, error) {
 				ch := make(chan []*Operation, 100)
@@ -327,8 +706,52 @@ func TestServiceGetMethods(t *testing.T) {
 			respFixture:     "fixtures/monitor/mempool_operations.chunked",
 			respContentType: "application/json",
 			expectedPath:    "/chains/main/mempool/monitor_operations",
+			expectedQuery:   "applied",
 			expectedValue:   []*Operation{{Protocol: "Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp9FeuAXJ4eV9otd", Branch: "BKvSZMWpcDc9RkKg11sQ5oRDyHrMDiKX5RmTdU455XnPHuYZWRS", Contents: OperationElements{&EndorsementOperationElem{GenericOperationElem: GenericOperationElem{Kind: "endorsement"}, Level: 489922}}, Signature: "sigbdfHsA4XHTB3ToUMzRRAYmSJBCvJ52jdE7SrFp7BD3jUnd9sVBdzytHKTD6ygy343jRjJvc4E8kuZRiEqUdExH333RaqP"}, {Protocol: "Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp9FeuAXJ4eV9otd", Branch: "BKvSZMWpcDc9RkKg11sQ5oRDyHrMDiKX5RmTdU455XnPHuYZWRS", Contents: OperationElements{&EndorsementOperationElem{GenericOperationElem: GenericOperationElem{Kind: "endorsement"}, Level: 489922}}, Signature: "sigk5ep31BR1gSFSD37aiiAbT2azciyBdBaZD8Xp4Ef1NCT37L9ggucZySHhrNEnmqKZSRq5LKq5MJDVhj4tKmP1z8GqmY5j"}},
 		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				ch := make(chan []*Operation, 100)
+				if err := s.MonitorMempool(ctx, "main", &MempoolFilter{Pools: []string{"applied", "branch_delayed"}, Explicit: true}, ch); err != nil {
+					return nil, err
+				}
+				close(ch)
+
+				var res []*Operation
+				for b := range ch {
+					res = append(res, b...)
+				}
+				return res, nil
+			},
+			respFixture:     "fixtures/monitor/mempool_operations.chunked",
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/mempool/monitor_operations",
+			expectedQuery:   "validated=true&refused=false&outdated=false&branch_refused=false&branch_delayed=true",
+			expectedValue:   []*Operation{{Protocol: "Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp9FeuAXJ4eV9otd", Branch: "BKvSZMWpcDc9RkKg11sQ5oRDyHrMDiKX5RmTdU455XnPHuYZWRS", Contents: OperationElements{&EndorsementOperationElem{GenericOperationElem: GenericOperationElem{Kind: "endorsement"}, Level: 489922}}, Signature: "sigbdfHsA4XHTB3ToUMzRRAYmSJBCvJ52jdE7SrFp7BD3jUnd9sVBdzytHKTD6ygy343jRjJvc4E8kuZRiEqUdExH333RaqP"}, {Protocol: "Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp9FeuAXJ4eV9otd", Branch: "BKvSZMWpcDc9RkKg11sQ5oRDyHrMDiKX5RmTdU455XnPHuYZWRS", Contents: OperationElements{&EndorsementOperationElem{GenericOperationElem: GenericOperationElem{Kind: "endorsement"}, Level: 489922}}, Signature: "sigk5ep31BR1gSFSD37aiiAbT2azciyBdBaZD8Xp4Ef1NCT37L9ggucZySHhrNEnmqKZSRq5LKq5MJDVhj4tKmP1z8GqmY5j"}},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				ch := make(chan []*OperationWithError, 100)
+				if err := s.MonitorMempoolWithErrors(ctx, "main", &MempoolFilter{Pools: []string{"validated", "outdated"}, Explicit: true}, ch); err != nil {
+					return nil, err
+				}
+				close(ch)
+
+				var res []*OperationWithError
+				for b := range ch {
+					res = append(res, b...)
+				}
+				return res, nil
+			},
+			respFixture:     "fixtures/monitor/mempool_operations_errors.chunked",
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/mempool/monitor_operations",
+			expectedQuery:   "validated=true&refused=false&outdated=true&branch_refused=false&branch_delayed=false",
+			expectedValue: []*OperationWithError{
+				{Operation: Operation{Protocol: "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ", Hash: "ooLwdDfXXeArN2VD3NSsPaCQyx7vAYsL2rW6VBYzKspJQgXYMB1", Branch: "BLTrbWKUY3qEd3nrpmqDTEyoq6GvrxpYBKRuXpXm3qRgo1qbNQH", Contents: OperationElements{&EndorsementOperationElem{GenericOperationElem: GenericOperationElem{Kind: "attestation"}, Level: 5726208}}, Signature: "sigS3gvTQ8U8pqnAEvgELJbsuHAryp9u6GtW1ekY1TxfjD3Ch8wdHCrWT6xHZtJUjjD9qvuWgKi8hkStfu8HWqKm5oPUqdcA"}},
+				{Operation: Operation{Protocol: "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ", Hash: "opJ4hB9bqLTzXpAfUDtmCBrvL7UbKHzRAwR3Tnq7D8u5y2MPDuU", Branch: "BLTrbWKUY3qEd3nrpmqDTEyoq6GvrxpYBKRuXpXm3qRgo1qbNQH", Contents: OperationElements{&EndorsementOperationElem{GenericOperationElem: GenericOperationElem{Kind: "attestation"}, Level: 5726200}}, Signature: "sigS3gvTQ8U8pqnAEvgELJbsuHAryp9u6GtW1ekY1TxfjD3Ch8wdHCrWT6xHZtJUjjD9qvuWgKi8hkStfu8HWqKm5oPUqdcA"}, Error: Errors{&GenericError{ID: "proto.019-PtParisB.validate.consensus_operation_for_old_level", Kind: "outdated"}}},
+			},
+		},
 		{
 			get: func(s *Service) (interface{}, error) {
 				return s.GetBallotList(ctx, "main", "head")
@@ -383,6 +806,15 @@ func TestServiceGetMethods(t *testing.T) {
 			expectedPath:    "/chains/main/blocks/head/votes/current_quorum",
 			expectedValue:   8000,
 		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetCurrentPeriod(ctx, "main", "head")
+			},
+			respFixture:     "fixtures/votes/current_period.json",
+			respContentType: "application/json",
+			expectedPath:    "/chains/main/blocks/head/votes/current_period",
+			expectedValue:   &VotingPeriodInfo{VotingPeriod: VotingPeriod{Index: 125, Kind: "exploration", StartPosition: 5709824}, Position: 16384, Remaining: 213631},
+		},
 		{
 			get: func(s *Service) (interface{}, error) {
 				return s.GetCurrentPeriodKind(ctx, "main", "head")
@@ -392,6 +824,49 @@ func TestServiceGetMethods(t *testing.T) {
 			expectedPath:    "/chains/main/blocks/head/votes/current_period_kind",
 			expectedValue:   PeriodKind("testing_vote"),
 		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetGCStats(ctx)
+			},
+			respFixture:     "fixtures/stats/gc.json",
+			respContentType: "application/json",
+			expectedPath:    "/stats/gc",
+			expectedValue:   &GCStats{MinorWords: 1045316470128, PromotedWords: 25473410338, MajorWords: 47329413843, MinorCollections: 3933672, MajorCollections: 3117, HeapWords: 165326336, HeapChunks: 18, LiveWords: 110938564, LiveBlocks: 19981573, FreeWords: 54298622, FreeBlocks: 1270843, LargestFree: 27344212, Fragments: 89150, Compactions: 4, TopHeapWords: 240357888, StackSize: 1089},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetVersion(ctx)
+			},
+			respFixture:     "fixtures/version.json",
+			respContentType: "application/json",
+			expectedPath:    "/version",
+			expectedValue: &VersionInfo{
+				Version:        NodeVersion{Major: 20, Minor: 0, AdditionalInfo: "rc1"},
+				NetworkVersion: NetworkAnnouncedVersion{ChainName: "TEZOS_MAINNET", DistributedDBVersion: 2, P2PVersion: 1},
+				CommitInfo:     &CommitInfo{CommitHash: "a8ad8b3b69b5b1a6a27e6f5e1f9d0b2b1e5c0b7c", CommitDate: "2024-05-13 09:01:27 +0200"},
+			},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetVersion(ctx)
+			},
+			respInline:      `{"version":{"major":19,"minor":1,"additional_info":"release"},"network_version":{"chain_name":"TEZOS_GHOSTNET_2022-01-25T15:00:00Z","distributed_db_version":2,"p2p_version":1},"commit_info":null}`,
+			respContentType: "application/json",
+			expectedPath:    "/version",
+			expectedValue: &VersionInfo{
+				Version:        NodeVersion{Major: 19, Minor: 1, AdditionalInfo: "release"},
+				NetworkVersion: NetworkAnnouncedVersion{ChainName: "TEZOS_GHOSTNET_2022-01-25T15:00:00Z", DistributedDBVersion: 2, P2PVersion: 1},
+			},
+		},
+		{
+			get: func(s *Service) (interface{}, error) {
+				return s.GetMemoryStats(ctx)
+			},
+			respFixture:     "fixtures/stats/memory.json",
+			respContentType: "application/json",
+			expectedPath:    "/stats/memory",
+			expectedValue:   &MemoryStats{PageSize: 4096, Size: 1561254, Resident: 731045, Shared: 9832, Text: 12044, Data: 893472},
+		},
 	}
 
 	for _, test := range tests {
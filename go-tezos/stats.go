@@ -0,0 +1,33 @@
+package tezos
+
+// GCStats holds the node's OCaml garbage collector statistics
+type GCStats struct {
+	MinorWords       float64 `json:"minor_words" yaml:"minor_words"`
+	PromotedWords    float64 `json:"promoted_words" yaml:"promoted_words"`
+	MajorWords       float64 `json:"major_words" yaml:"major_words"`
+	MinorCollections int64   `json:"minor_collections" yaml:"minor_collections"`
+	MajorCollections int64   `json:"major_collections" yaml:"major_collections"`
+	HeapWords        int64   `json:"heap_words" yaml:"heap_words"`
+	HeapChunks       int64   `json:"heap_chunks" yaml:"heap_chunks"`
+	LiveWords        int64   `json:"live_words" yaml:"live_words"`
+	LiveBlocks       int64   `json:"live_blocks" yaml:"live_blocks"`
+	FreeWords        int64   `json:"free_words" yaml:"free_words"`
+	FreeBlocks       int64   `json:"free_blocks" yaml:"free_blocks"`
+	LargestFree      int64   `json:"largest_free" yaml:"largest_free"`
+	Fragments        int64   `json:"fragments" yaml:"fragments"`
+	Compactions      int64   `json:"compactions" yaml:"compactions"`
+	TopHeapWords     int64   `json:"top_heap_words" yaml:"top_heap_words"`
+	StackSize        int64   `json:"stack_size" yaml:"stack_size"`
+}
+
+// MemoryStats holds the node's process memory statistics as reported by statm(5), in pages
+type MemoryStats struct {
+	PageSize int64 `json:"page_size" yaml:"page_size"`
+	Size     int64 `json:"size,string" yaml:"size"`
+	Resident int64 `json:"resident,string" yaml:"resident"`
+	Shared   int64 `json:"shared,string" yaml:"shared"`
+	Text     int64 `json:"text,string" yaml:"text"`
+	Lib      int64 `json:"lib,string" yaml:"lib"`
+	Data     int64 `json:"data,string" yaml:"data"`
+	Dt       int64 `json:"dt,string" yaml:"dt"`
+}
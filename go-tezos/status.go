@@ -0,0 +1,86 @@
+package tezos
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ChainStatus aggregates the RPCs commonly used to check the node health. Fields of the failed requests are left zero
+// and their errors are kept in Errors keyed by the RPC path.
+type ChainStatus struct {
+	Bootstrapped *BootstrappedStatus
+	Head         *BlockHeader
+	Version      *VersionInfo
+	Connections  int
+	Errors       map[string]error
+}
+
+// Err returns the combined error of the failed requests or nil
+func (c *ChainStatus) Err() error {
+	if len(c.Errors) == 0 {
+		return nil
+	}
+	paths := make([]string, 0, len(c.Errors))
+	for p := range c.Errors {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	msgs := make([]string, len(paths))
+	for i, p := range paths {
+		msgs[i] = p + ": " + c.Errors[p].Error()
+	}
+	return errors.New("tezos: " + strings.Join(msgs, "; "))
+}
+
+// GetChainStatus concurrently requests the bootstrap status, the head header, the node version and the connections.
+// Failed requests don't prevent the others from being reported, the returned error is only set if all of them failed.
+func (s *Service) GetChainStatus(ctx context.Context, chainID string) (*ChainStatus, error) {
+	var (
+		status ChainStatus
+		mtx    sync.Mutex
+		wg     sync.WaitGroup
+	)
+
+	run := func(path string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mtx.Lock()
+				if status.Errors == nil {
+					status.Errors = make(map[string]error)
+				}
+				status.Errors[path] = err
+				mtx.Unlock()
+			}
+		}()
+	}
+
+	run("/chains/"+chainID+"/is_bootstrapped", func() (err error) {
+		status.Bootstrapped, err = s.GetBootstrapped(ctx, chainID)
+		return err
+	})
+	run("/chains/"+chainID+"/blocks/head/header", func() (err error) {
+		status.Head, err = s.GetBlockHeader(ctx, chainID, "head")
+		return err
+	})
+	run("/version", func() (err error) {
+		status.Version, err = s.GetVersion(ctx)
+		return err
+	})
+	run("/network/connections", func() error {
+		conns, err := s.GetNetworkConnections(ctx)
+		status.Connections = len(conns)
+		return err
+	})
+	wg.Wait()
+
+	if len(status.Errors) == 4 {
+		return nil, status.Err()
+	}
+	return &status, nil
+}
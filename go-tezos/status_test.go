@@ -0,0 +1,54 @@
+package tezos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChainStatus(t *testing.T) {
+	fixtures := map[string]string{
+		"/version":             "fixtures/version.json",
+		"/network/connections": "fixtures/network/connections.json",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/chains/main/is_bootstrapped":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"bootstrapped":true,"sync_state":"synced"}`))
+		case "/version", "/network/connections":
+			buf, err := ioutil.ReadFile(fixtures[r.URL.Path])
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(buf)
+		default:
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	s := &Service{Client: c}
+
+	status, err := s.GetChainStatus(context.Background(), "main")
+	require.NoError(t, err)
+	require.Equal(t, &BootstrappedStatus{Bootstrapped: true, SyncState: SyncStateSynced}, status.Bootstrapped)
+	require.NotNil(t, status.Version)
+	require.NotZero(t, status.Connections)
+	require.Nil(t, status.Head)
+	require.Len(t, status.Errors, 1)
+	require.Contains(t, status.Errors, "/chains/main/blocks/head/header")
+	require.Error(t, status.Err())
+
+	srv.Close()
+	_, err = s.GetChainStatus(context.Background(), "main")
+	require.Error(t, err)
+}
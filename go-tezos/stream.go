@@ -0,0 +1,102 @@
+package tezos
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// StreamDecoder decodes consecutive values from a monitor response body
+type StreamDecoder interface {
+	Decode(v interface{}) error
+}
+
+// StreamTransport adapts monitor requests and responses to the way a server delivers streams
+type StreamTransport interface {
+	// PrepareRequest modifies the monitor request before it's sent
+	PrepareRequest(req *http.Request)
+	// NewDecoder returns a decoder reading the stream values from the response body
+	NewDecoder(r io.Reader) StreamDecoder
+}
+
+// autoStream chooses the decoder by the response content type
+func autoStream(resp *http.Response) StreamTransport {
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return SSEStream{}
+	}
+	return ChunkedJSONStream{}
+}
+
+// ChunkedJSONStream is a default stream transport used by Tezos nodes: a sequence of JSON values in a chunked response
+type ChunkedJSONStream struct{}
+
+// PrepareRequest implements StreamTransport
+func (ChunkedJSONStream) PrepareRequest(req *http.Request) {}
+
+// NewDecoder implements StreamTransport
+func (ChunkedJSONStream) NewDecoder(r io.Reader) StreamDecoder {
+	return json.NewDecoder(r)
+}
+
+// SSEStream is a stream transport for providers delivering monitor values as server-sent events with JSON data
+type SSEStream struct{}
+
+// PrepareRequest implements StreamTransport
+func (SSEStream) PrepareRequest(req *http.Request) {
+	req.Header.Set("Accept", "text/event-stream")
+}
+
+// NewDecoder implements StreamTransport
+func (SSEStream) NewDecoder(r io.Reader) StreamDecoder {
+	return &sseDecoder{r: bufio.NewReader(r)}
+}
+
+type sseDecoder struct {
+	r *bufio.Reader
+	// Disallow unknown fields in event data
+	strict bool
+}
+
+func (d *sseDecoder) DisallowUnknownFields() {
+	d.strict = true
+}
+
+// Decode reads events until one with non empty data arrives and decodes it into v
+func (d *sseDecoder) Decode(v interface{}) error {
+	var data bytes.Buffer
+	for {
+		line, err := d.r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		eof := err == io.EOF
+		line = strings.TrimRight(line, "\r\n")
+
+		// Comments, event names, ids and retry fields are ignored
+		if strings.HasPrefix(line, "data:") {
+			appendSSEData(&data, line)
+		}
+
+		if (line == "" || eof) && data.Len() != 0 {
+			dec := json.NewDecoder(&data)
+			if d.strict {
+				dec.DisallowUnknownFields()
+			}
+			return dec.Decode(v)
+		}
+		if eof {
+			return io.EOF
+		}
+	}
+}
+
+func appendSSEData(buf *bytes.Buffer, line string) {
+	if buf.Len() != 0 {
+		buf.WriteByte('\n')
+	}
+	value := strings.TrimPrefix(line, "data:")
+	buf.WriteString(strings.TrimPrefix(value, " "))
+}
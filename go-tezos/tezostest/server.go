@@ -0,0 +1,192 @@
+// Package tezostest provides a Tezos RPC server replaying fixtures for tests. Besides plain responses it simulates
+// chunked monitor streams with delays between chunks, dropped connections and malformed chunks.
+package tezostest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Response describes the reply to a request
+type Response struct {
+	// Status code. Zero means 200.
+	Status int
+	// Content type. Empty means application/json.
+	ContentType string
+	// Body of a plain response
+	Body []byte
+	// Chunks makes the response a chunked stream, every chunk is flushed separately. Malformed chunks are sent as is.
+	Chunks [][]byte
+	// Delay before every chunk
+	Delay time.Duration
+	// Hold keeps the stream open after the last chunk until the client goes away or the server is closed
+	Hold bool
+	// Disconnect drops the connection after the last chunk without terminating the stream
+	Disconnect bool
+}
+
+// Fixture returns a response with the file contents. Lines of .chunked files become stream chunks, empty lines are skipped.
+func Fixture(file string) (*Response, error) {
+	buf, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if filepath.Ext(file) != ".chunked" {
+		return &Response{Body: buf}, nil
+	}
+
+	var chunks [][]byte
+	for _, line := range bytes.Split(buf, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) != 0 {
+			chunks = append(chunks, append(line, '\n'))
+		}
+	}
+	return &Response{Chunks: chunks}, nil
+}
+
+// MustFixture is like Fixture but panics on error
+func MustFixture(file string) *Response {
+	r, err := Fixture(file)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+type route struct {
+	pattern   string
+	responses []*Response
+	requests  int
+}
+
+// Server is a test Tezos RPC server. Request paths are matched against the route patterns using path.Match
+// in the order the routes were added, e.g. "/chains/main/blocks/*" matches any block but not its subpaths.
+// Unmatched requests get 404.
+type Server struct {
+	*httptest.Server
+
+	mtx    sync.Mutex
+	routes []*route
+	closed chan struct{}
+	once   sync.Once
+}
+
+// NewServer starts and returns a new Server
+func NewServer() *Server {
+	s := &Server{
+		closed: make(chan struct{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Handle adds a route. Consecutive requests are replied with the responses in turn, the last one is repeated.
+func (s *Server) Handle(pattern string, responses ...*Response) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.routes = append(s.routes, &route{
+		pattern:   pattern,
+		responses: responses,
+	})
+}
+
+// Requests returns the number of requests matched by the pattern
+func (s *Server) Requests(pattern string) int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var n int
+	for _, r := range s.routes {
+		if r.pattern == pattern {
+			n += r.requests
+		}
+	}
+	return n
+}
+
+// Close drops streams being held and shuts down the server
+func (s *Server) Close() {
+	s.once.Do(func() { close(s.closed) })
+	s.Server.CloseClientConnections()
+	s.Server.Close()
+}
+
+func (s *Server) match(p string) *Response {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, r := range s.routes {
+		if ok, _ := path.Match(r.pattern, p); !ok || len(r.responses) == 0 {
+			continue
+		}
+		i := r.requests
+		if i >= len(r.responses) {
+			i = len(r.responses) - 1
+		}
+		r.requests++
+		return r.responses[i]
+	}
+	return nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	resp := s.match(req.URL.Path)
+	if resp == nil {
+		http.NotFound(w, req)
+		return
+	}
+
+	contentType := resp.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	w.Header().Set("Content-Type", contentType)
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	if resp.Chunks == nil {
+		w.WriteHeader(status)
+		w.Write(resp.Body)
+		return
+	}
+
+	// No Content-Length makes the response chunked
+	w.WriteHeader(status)
+	flusher := w.(http.Flusher)
+	flusher.Flush()
+
+	for _, chunk := range resp.Chunks {
+		if resp.Delay != 0 {
+			select {
+			case <-time.After(resp.Delay):
+			case <-req.Context().Done():
+				return
+			case <-s.closed:
+				return
+			}
+		}
+		if _, err := w.Write(chunk); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+
+	switch {
+	case resp.Disconnect:
+		if conn, _, err := w.(http.Hijacker).Hijack(); err == nil {
+			conn.Close()
+		}
+	case resp.Hold:
+		select {
+		case <-req.Context().Done():
+		case <-s.closed:
+		}
+	}
+}
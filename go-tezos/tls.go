@@ -0,0 +1,59 @@
+package tezos
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net/http"
+)
+
+// TLSOptions configures TLS connections to nodes behind TLS terminating proxies
+type TLSOptions struct {
+	// PEM file of the CA certificates trusted in addition to the system ones
+	CAFile string
+	// PEM files of the client certificate and its key for mutual TLS
+	CertFile string
+	KeyFile  string
+	// Don't verify the server certificate
+	InsecureSkipVerify bool
+}
+
+// Config returns the TLS client configuration
+func (o *TLSOptions) Config() (*tls.Config, error) {
+	cfg := &tls.Config{
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.CAFile != "" {
+		pem, err := ioutil.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("tezos: no certificates found in " + o.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if o.CertFile != "" || o.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// NewTransport returns a copy of http.DefaultTransport using the TLS configuration
+func NewTransport(cfg *tls.Config) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = cfg
+	return t
+}
@@ -0,0 +1,23 @@
+package tezos
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// unixBaseURL replaces unix:// URLs in requests as the socket path isn't a part of the request URL
+var unixBaseURL = url.URL{Scheme: "http", Host: "localhost"}
+
+// NewUnixTransport returns a copy of http.DefaultTransport connecting to the Unix domain socket regardless of the request address.
+// Proxies are never used.
+func NewUnixTransport(socket string) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", socket)
+	}
+	return t
+}
@@ -82,7 +82,7 @@ func dumpRequest(logger Logger, level log.Level, req *http.Request) {
 	w := logger.WriterLevel(level)
 	defer w.Close()
 
-	w.Write(buf)
+	w.Write(redactAuthorization(buf))
 }
 
 func dumpResponse(logger Logger, level log.Level, res *http.Response, body bool) {
@@ -0,0 +1,64 @@
+package tezos
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// VersionAdditionalInfo is the release kind of the node: "release", "dev", "rc<N>" or "beta<N>"
+type VersionAdditionalInfo string
+
+// UnmarshalJSON implements json.Unmarshaler. Candidates are encoded as {"rc": N} or {"beta": N} objects.
+func (v *VersionAdditionalInfo) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = VersionAdditionalInfo(s)
+		return nil
+	}
+
+	var obj map[string]int
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	if len(obj) != 1 {
+		return fmt.Errorf("tezos: unexpected version additional info: %s", data)
+	}
+	for k, n := range obj {
+		*v = VersionAdditionalInfo(k + strconv.Itoa(n))
+	}
+	return nil
+}
+
+// NodeVersion holds the node's software version
+type NodeVersion struct {
+	Major          int                   `json:"major" yaml:"major"`
+	Minor          int                   `json:"minor" yaml:"minor"`
+	AdditionalInfo VersionAdditionalInfo `json:"additional_info" yaml:"additional_info"`
+}
+
+// String returns the version in the Octez notation, e.g. 19.1, 20.0~rc1 or 20.0+dev
+func (v *NodeVersion) String() string {
+	s := strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor)
+	switch v.AdditionalInfo {
+	case "", "release":
+		return s
+	case "dev":
+		return s + "+dev"
+	default:
+		return s + "~" + string(v.AdditionalInfo)
+	}
+}
+
+// CommitInfo holds the commit the node was built from
+type CommitInfo struct {
+	CommitHash string `json:"commit_hash" yaml:"commit_hash"`
+	CommitDate string `json:"commit_date" yaml:"commit_date"`
+}
+
+// VersionInfo holds the node's software, network and commit versions
+type VersionInfo struct {
+	Version        NodeVersion             `json:"version" yaml:"version"`
+	NetworkVersion NetworkAnnouncedVersion `json:"network_version" yaml:"network_version"`
+	CommitInfo     *CommitInfo             `json:"commit_info" yaml:"commit_info"`
+}
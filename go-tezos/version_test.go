@@ -0,0 +1,24 @@
+package tezos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeVersionString(t *testing.T) {
+	for src, expected := range map[string]string{
+		`{"major":19,"minor":1,"additional_info":"release"}`:  "19.1",
+		`{"major":20,"minor":0,"additional_info":"dev"}`:      "20.0+dev",
+		`{"major":20,"minor":0,"additional_info":{"rc":2}}`:   "20.0~rc2",
+		`{"major":20,"minor":0,"additional_info":{"beta":1}}`: "20.0~beta1",
+	} {
+		var v NodeVersion
+		require.NoError(t, json.Unmarshal([]byte(src), &v))
+		require.Equal(t, expected, v.String())
+	}
+
+	var v NodeVersion
+	require.Error(t, json.Unmarshal([]byte(`{"major":20,"minor":0,"additional_info":{"rc":1,"beta":1}}`), &v))
+}
@@ -25,6 +25,20 @@ type Proposal struct {
 	SupporterCount int
 }
 
+// VotingPeriod holds information about a voting period
+type VotingPeriod struct {
+	Index         int        `json:"index" yaml:"index"`
+	Kind          PeriodKind `json:"kind" yaml:"kind"`
+	StartPosition int        `json:"start_position" yaml:"start_position"`
+}
+
+// VotingPeriodInfo holds the voting period of a block and the block position within it
+type VotingPeriodInfo struct {
+	VotingPeriod VotingPeriod `json:"voting_period" yaml:"voting_period"`
+	Position     int          `json:"position" yaml:"position"`
+	Remaining    int          `json:"remaining" yaml:"remaining"`
+}
+
 // PeriodKind contains information about tezos voting period kind
 type PeriodKind string
 
@@ -0,0 +1,44 @@
+package tezos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Worker phases
+const (
+	WorkerLaunching = "launching"
+	WorkerRunning   = "running"
+	WorkerClosing   = "closing"
+	WorkerClosed    = "closed"
+)
+
+// WorkerStatus is the phase of a node worker. Birth is set for closing and closed workers, Errors for the ones closed after a failure.
+type WorkerStatus struct {
+	Phase  string     `json:"phase" yaml:"phase"`
+	Since  time.Time  `json:"since" yaml:"since"`
+	Birth  *time.Time `json:"birth,omitempty" yaml:"birth,omitempty"`
+	Errors Errors     `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// WorkerRequest is a request queued or being handled by a node worker. The request itself is worker specific.
+type WorkerRequest struct {
+	Pushed  time.Time       `json:"pushed" yaml:"pushed"`
+	Treated *time.Time      `json:"treated,omitempty" yaml:"treated,omitempty"`
+	Request json.RawMessage `json:"request" yaml:"-"`
+}
+
+// WorkerState is the detailed state of a node worker
+type WorkerState struct {
+	Status          WorkerStatus     `json:"status" yaml:"status"`
+	PendingRequests []*WorkerRequest `json:"pending_requests" yaml:"pending_requests"`
+	CurrentRequest  *WorkerRequest   `json:"current_request,omitempty" yaml:"current_request,omitempty"`
+}
+
+// ChainWorker is an element of the per chain workers list. Information is worker specific.
+type ChainWorker struct {
+	ChainID     string          `json:"chain_id" yaml:"chain_id"`
+	Status      WorkerStatus    `json:"status" yaml:"status"`
+	Information json.RawMessage `json:"information,omitempty" yaml:"-"`
+	Pipelines   int             `json:"pipelines,omitempty" yaml:"pipelines,omitempty"`
+}
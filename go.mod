@@ -1,14 +1,25 @@
 module github.com/ecadlabs/tezos_exporter
 
-go 1.12
+go 1.19
 
 require (
 	github.com/davecgh/go-spew v1.1.1
-	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/prometheus/client_golang v1.11.0
-	github.com/prometheus/common v0.29.0 // indirect
+	github.com/prometheus/client_model v0.2.0
+	github.com/prometheus/procfs v0.6.0
 	github.com/sirupsen/logrus v1.8.1
-	github.com/stretchr/testify v1.7.0
-	golang.org/x/sys v0.0.0-20210611083646-a4fc73990273 // indirect
-	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
+	github.com/stretchr/testify v1.7.1
+	go.uber.org/automaxprocs v1.5.3
+	golang.org/x/sys v0.0.0-20210611083646-a4fc73990273
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/common v0.29.0 // indirect
+	google.golang.org/protobuf v1.26.0 // indirect
 )
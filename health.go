@@ -4,43 +4,76 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
 	"time"
 
 	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 )
 
+var (
+	healthOkDesc = prometheus.NewDesc(
+		"tezos_exporter_health_ok",
+		"The node health status reported by /health.",
+		nil,
+		nil)
+
+	healthUptimeDesc = prometheus.NewDesc(
+		"tezos_exporter_health_uptime_ratio",
+		"The fraction of time the node was healthy during the window.",
+		[]string{"window"},
+		nil)
+)
+
 type HealthHandler struct {
 	service   *tezos.Service
+	timeout   time.Duration
 	interval  time.Duration
 	chainID   string
 	threshold int
-	tcount    int
-	ok        bool
+	history   *healthHistory
+
+	mtx    sync.Mutex
+	tcount int
+	ok     bool
+}
+
+// check returns true if the node is bootstrapped and synced
+func (h *HealthHandler) check() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	status, err := h.service.GetChainStatus(ctx, h.chainID)
+	if err != nil {
+		return false, err
+	}
+	if status.Bootstrapped == nil {
+		return false, status.Err()
+	}
+	return status.Bootstrapped.Bootstrapped && status.Bootstrapped.SyncState == tezos.SyncStateSynced, nil
 }
 
 func (h *HealthHandler) poll() {
-	status, err := h.service.GetBootstrapped(context.Background(), h.chainID)
+	ok, err := h.check()
+	h.mtx.Lock()
 	if err != nil {
 		log.WithError(err).Error("error getting bootstrap status")
-		h.ok = false
-	} else {
-		h.ok = status.Bootstrapped && status.SyncState == tezos.SyncStateSynced
 	}
+	h.ok = ok
 	h.tcount = h.threshold
+	h.history.record(h.ok, time.Now())
+	h.mtx.Unlock()
 
 	tick := time.Tick(h.interval)
 	for range tick {
-		status, err := h.service.GetBootstrapped(context.Background(), h.chainID)
+		ok, err := h.check()
+		h.mtx.Lock()
 		if err != nil {
 			log.WithError(err).Error("error getting bootstrap status")
 			h.ok = false
 			h.tcount = h.threshold
-			continue
-		}
-
-		ok := status.Bootstrapped && status.SyncState == tezos.SyncStateSynced
-		if ok != h.ok {
+		} else if ok != h.ok {
 			h.tcount--
 			if h.tcount == 0 {
 				h.tcount = h.threshold
@@ -49,16 +82,25 @@ func (h *HealthHandler) poll() {
 		} else {
 			h.tcount = h.threshold
 		}
+		h.history.record(h.ok, time.Now())
+		h.mtx.Unlock()
 	}
 }
 
+// isOK returns the current health status
+func (h *HealthHandler) isOK() bool {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return h.ok
+}
+
 func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var res struct {
 		Bootstrapped bool `json:"bootstrapped"`
 	}
 
 	var status int
-	if h.ok {
+	if h.isOK() {
 		status = http.StatusOK
 		res.Bootstrapped = true
 	} else {
@@ -71,12 +113,38 @@ func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(&res)
 }
 
-func NewHealthHandler(service *tezos.Service, chainID string, interval time.Duration, threshold int) *HealthHandler {
+// Describe implements prometheus.Collector
+func (h *HealthHandler) Describe(ch chan<- *prometheus.Desc) {
+	ch <- healthOkDesc
+	ch <- healthUptimeDesc
+}
+
+// Collect implements prometheus.Collector
+func (h *HealthHandler) Collect(ch chan<- prometheus.Metric) {
+	var ok float64
+	if h.isOK() {
+		ok = 1
+	}
+	ch <- prometheus.MustNewConstMetric(healthOkDesc, prometheus.GaugeValue, ok)
+
+	now := time.Now()
+	for _, w := range historyWindows {
+		ch <- prometheus.MustNewConstMetric(healthUptimeDesc, prometheus.GaugeValue, h.history.uptime(w.d, now), w.name)
+	}
+}
+
+// NewHealthHandler returns a new HealthHandler. Every poll is limited by timeout. Its metrics are registered with reg if not nil.
+func NewHealthHandler(service *tezos.Service, chainID string, timeout, interval time.Duration, threshold int, reg prometheus.Registerer) *HealthHandler {
 	h := HealthHandler{
 		service:   service,
+		timeout:   timeout,
 		interval:  interval,
 		threshold: threshold,
 		chainID:   chainID,
+		history:   newHealthHistory(time.Now()),
+	}
+	if reg != nil {
+		reg.MustRegister(&h)
 	}
 	go h.poll()
 	return &h
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(t *testing.T, srv *tezostest.Server) *tezos.Service {
+	client, err := tezos.NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	return &tezos.Service{Client: client}
+}
+
+func TestHealthHandler(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/chains/main/is_bootstrapped",
+		&tezostest.Response{Body: []byte(`{"bootstrapped":false,"sync_state":"unsynced"}`)},
+		&tezostest.Response{Body: []byte(`{"bootstrapped":true,"sync_state":"synced"}`)},
+	)
+
+	reg := prometheus.NewPedanticRegistry()
+	h := NewHealthHandler(newTestService(t, srv), "main", time.Second, 5*time.Millisecond, 2, reg)
+
+	// The status becomes ok after the threshold number of polls while being served and collected concurrently
+	require.Eventually(t, func() bool {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		n, err := testutil.GatherAndCount(reg, "tezos_exporter_health_ok")
+		return err == nil && n == 1 && w.Code == http.StatusOK
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestHealthHandlerTimeout(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	// The first poll hangs until it times out
+	srv.Handle("/chains/main/is_bootstrapped",
+		&tezostest.Response{Chunks: [][]byte{[]byte(`{"bootstrapped":true,"sync_state":"synced"}`)}, Delay: time.Hour},
+		&tezostest.Response{Body: []byte(`{"bootstrapped":true,"sync_state":"synced"}`)},
+	)
+
+	h := NewHealthHandler(newTestService(t, srv), "main", 50*time.Millisecond, 5*time.Millisecond, 2, nil)
+	require.Eventually(t, h.isOK, 5*time.Second, time.Millisecond)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// historyRetention is the longest window uptime is reported for
+const historyRetention = 7 * 24 * time.Hour
+
+// historyWindows are the windows reported by HistoryHandler
+var historyWindows = []struct {
+	name string
+	d    time.Duration
+}{
+	{"1h", time.Hour},
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+}
+
+type healthTransition struct {
+	t  time.Time
+	ok bool
+}
+
+// healthHistory keeps the health status transitions for the last historyRetention period
+type healthHistory struct {
+	mtx         sync.Mutex
+	start       time.Time
+	transitions []healthTransition
+}
+
+func newHealthHistory(now time.Time) *healthHistory {
+	return &healthHistory{start: now}
+}
+
+// record registers the status observed at the given moment
+func (h *healthHistory) record(ok bool, now time.Time) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if n := len(h.transitions); n == 0 || h.transitions[n-1].ok != ok {
+		h.transitions = append(h.transitions, healthTransition{t: now, ok: ok})
+	}
+
+	// Keep the last transition before the cutoff as it defines the status at the beginning of the window
+	cutoff := now.Add(-historyRetention)
+	i := 0
+	for i < len(h.transitions)-1 && !h.transitions[i+1].t.After(cutoff) {
+		i++
+	}
+	h.transitions = h.transitions[i:]
+}
+
+// uptime returns the fraction of time the status was ok during the window. Time before the first observation is not accounted.
+func (h *healthHistory) uptime(window time.Duration, now time.Time) float64 {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	from := now.Add(-window)
+	if len(h.transitions) == 0 {
+		return 0
+	}
+	if first := h.transitions[0].t; from.Before(first) {
+		from = first
+	}
+	total := now.Sub(from)
+	if total <= 0 {
+		if h.transitions[len(h.transitions)-1].ok {
+			return 1
+		}
+		return 0
+	}
+
+	var up time.Duration
+	for i, tr := range h.transitions {
+		end := now
+		if i+1 < len(h.transitions) {
+			end = h.transitions[i+1].t
+		}
+		if !end.After(from) || !tr.ok {
+			continue
+		}
+		begin := tr.t
+		if begin.Before(from) {
+			begin = from
+		}
+		up += end.Sub(begin)
+	}
+	return float64(up) / float64(total)
+}
+
+// HistoryHandler serves the health uptime percentages over the last 1h, 24h and 7d
+type HistoryHandler struct {
+	history *healthHistory
+}
+
+func (h *HistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	res := struct {
+		Since  time.Time          `json:"since"`
+		Uptime map[string]float64 `json:"uptime_percent"`
+	}{
+		Since:  h.history.start,
+		Uptime: make(map[string]float64, len(historyWindows)),
+	}
+	for _, w := range historyWindows {
+		res.Uptime[w.name] = h.history.uptime(w.d, now) * 100
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(&res)
+}
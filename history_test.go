@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHistoryUptime(t *testing.T) {
+	t0 := time.Date(2024, 7, 22, 9, 0, 0, 0, time.UTC)
+	h := newHealthHistory(t0)
+	require.Equal(t, float64(0), h.uptime(time.Hour, t0))
+
+	h.record(true, t0)
+	h.record(true, t0.Add(10*time.Minute))
+	h.record(false, t0.Add(30*time.Minute))
+	h.record(true, t0.Add(45*time.Minute))
+	require.Len(t, h.transitions, 3)
+
+	tests := []struct {
+		window time.Duration
+		now    time.Time
+		uptime float64
+	}{
+		{window: time.Hour, now: t0, uptime: 1},
+		{window: time.Hour, now: t0.Add(time.Hour), uptime: 0.75},
+		// Time before the first observation is not accounted
+		{window: 24 * time.Hour, now: t0.Add(time.Hour), uptime: 0.75},
+		{window: 25 * time.Minute, now: t0.Add(time.Hour), uptime: 0.6},
+		{window: 10 * time.Minute, now: t0.Add(time.Hour), uptime: 1},
+		{window: 20 * time.Minute, now: t0.Add(45 * time.Minute), uptime: 0.25},
+	}
+	for _, test := range tests {
+		require.InDelta(t, test.uptime, h.uptime(test.window, test.now), 1e-9, "%v at %v", test.window, test.now)
+	}
+}
+
+func TestHealthHistoryRetention(t *testing.T) {
+	t0 := time.Date(2024, 7, 22, 9, 0, 0, 0, time.UTC)
+	h := newHealthHistory(t0)
+	h.record(true, t0)
+	h.record(false, t0.Add(time.Hour))
+	h.record(false, t0.Add(2*time.Hour))
+	h.record(true, t0.Add(historyRetention+2*time.Hour))
+
+	// The transition before the cutoff is kept as it defines the status at the beginning of the window
+	require.Equal(t, []healthTransition{
+		{t: t0.Add(time.Hour), ok: false},
+		{t: t0.Add(historyRetention + 2*time.Hour), ok: true},
+	}, h.transitions)
+	require.InDelta(t, 1.0/168, h.uptime(historyRetention, t0.Add(historyRetention+3*time.Hour)), 1e-9)
+}
+
+func TestHistoryHandler(t *testing.T) {
+	start := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	h := newHealthHistory(start)
+	h.record(false, start)
+	h.record(true, start.Add(time.Hour))
+
+	w := httptest.NewRecorder()
+	(&HistoryHandler{history: h}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/history", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Since  time.Time          `json:"since"`
+		Uptime map[string]float64 `json:"uptime_percent"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.True(t, start.Equal(res.Since))
+	require.InDelta(t, 100, res.Uptime["1h"], 0.01)
+	require.InDelta(t, 50, res.Uptime["24h"], 0.01)
+	require.InDelta(t, 50, res.Uptime["7d"], 0.01)
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// parseLabels parses a comma separated list of name=value pairs
+func parseLabels(s string) (prometheus.Labels, error) {
+	labels := make(prometheus.Labels)
+	for _, kv := range strings.Split(s, ",") {
+		i := strings.IndexByte(kv, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("malformed label: %q", kv)
+		}
+		labels[strings.TrimSpace(kv[:i])] = strings.TrimSpace(kv[i+1:])
+	}
+	return labels, nil
+}
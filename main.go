@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"net/http"
+	"net/url"
 	"os"
+	"runtime/debug"
 	"strings"
 	"time"
 
@@ -12,45 +15,579 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
+	"go.uber.org/automaxprocs/maxprocs"
 )
 
 func main() {
+	var soak *SoakTest
+	if len(os.Args) > 1 && os.Args[1] == "soak" {
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		soak = NewSoakTest()
+	}
+
 	metricsAddr := flag.String("metrics-listen-addr", ":9489", "TCP address on which to serve Prometheus metrics")
-	tezosAddr := flag.String("tezos-node-url", "http://localhost:8732", "URL of Tezos node to monitor")
+	tezosAddr := flag.String("tezos-node-url", "http://localhost:8732", "URL of Tezos node to monitor, unix:///path/to/socket to connect over the Unix domain socket")
+	tlsCAFile := flag.String("tezos-node-ca-file", "", "PEM file of the CA certificates to trust in addition to the system ones when connecting to the node over TLS")
+	tlsCertFile := flag.String("tezos-node-cert-file", "", "PEM file of the client certificate for mutual TLS")
+	tlsKeyFile := flag.String("tezos-node-key-file", "", "PEM file of the client certificate key for mutual TLS")
+	tlsInsecure := flag.Bool("tezos-node-insecure-skip-verify", false, "Don't verify the node's TLS certificate")
+	rpcProxy := flag.String("tezos-node-proxy", "", "URL of the HTTP(S) or SOCKS5 proxy of the node RPC requests, HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored if not set")
+	rpcCompression := flag.Bool("rpc-compression", true, "Request gzip compressed RPC responses, including streams. Nodes not supporting it reply uncompressed")
+	rpcMaxIdleConns := flag.Int("rpc-max-idle-conns", http.DefaultMaxIdleConnsPerHost, "Maximum number of idle keep-alive connections to the node shared by all collectors")
+	rpcIdleConnTimeout := flag.Duration("rpc-idle-conn-timeout", 90*time.Second, "Time after which idle keep-alive connections to the node are closed. Zero means no limit")
+	rpcTLSHandshakeTimeout := flag.Duration("rpc-tls-handshake-timeout", 10*time.Second, "Timeout of TLS handshakes with the node. Zero means no timeout")
+	rpcUsername := flag.String("tezos-node-username", "", "User name of the HTTP basic auth sent with every node RPC request")
+	rpcPasswordFile := flag.String("tezos-node-password-file", "", "File containing the HTTP basic auth password")
+	rpcTokenFile := flag.String("tezos-node-bearer-token-file", "", "File containing the bearer token sent with every node RPC request, takes precedence over the basic auth")
+	octezConfigPath := flag.String("octez-config-path", "", "Path to the node's config.json to discover the RPC endpoint from, unless -tezos-node-url is given")
 	chainID := flag.String("chain-id", "main", "ID of chain about which to report chain-related stats")
 	rpcTimeout := flag.Duration("rpc-timeout", 10*time.Second, "Timeout for connecting to tezos RPCs")
 	noHealthEp := flag.Bool("disable-health-endpoint", false, "Disable /health endpoint")
 	isBootstrappedPollInterval := flag.Duration("bootstraped-poll-interval", 10*time.Second, "is_bootstrapped endpoint polling interval")
 	isBootstrappedThreshold := flag.Int("bootstraped-threshold", 3, "Report is_bootstrapped change after N samples of the same value")
 	mempoolRetryInterval := flag.Duration("mempool-retry-delay", 30*time.Second, "Retry mempool monitoring after a delay in case of an error")
+	headRetryInterval := flag.Duration("head-retry-delay", 30*time.Second, "Retry chain head monitoring after a delay in case of an error")
 	pools := flag.String("mempool-pools", "applied,branch_refused,refused,branch_delayed", "Mempool pools")
+	mempoolSingleStream := flag.Bool("mempool-single-stream", false, "Request all mempool pools in one stream and classify operations by their errors instead of one stream per pool")
+	mempoolExplicitQuery := flag.Bool("mempool-explicit-query", false, "Request mempool pools with the explicit validated=true&refused=false... query form required by newer nodes")
+	gcPercent := flag.Int("gc-percent", 0, "Garbage collection target percentage (see GOGC), 0 to keep the runtime default, negative to disable GC")
+	memoryLimit := flag.Int64("memory-limit", 0, "Runtime soft memory limit in bytes (see GOMEMLIMIT), 0 to keep the runtime default")
+	softMemoryLimit := flag.Uint64("shed-memory-limit", 0, "Skip expensive collectors while the process memory exceeds this many bytes, 0 to disable")
+	invalidBlockInfo := flag.Bool("invalid-block-info", false, "Report hash and level of the latest invalid block")
+	nodeDataDir := flag.String("node-data-dir", "", "Data directory of a co-located node to report filesystem and storage usage for")
+	storageSizeInterval := flag.Duration("storage-size-interval", 5*time.Minute, "Interval between computations of the node data directory storage size")
+	nodePidFile := flag.String("node-pid-file", "", "PID file of a co-located node to report process metrics for")
+	nodeProcessName := flag.String("node-process-name", "", "Name of a co-located node process to report process metrics for, if no PID file is given")
+	okConditions := flag.String("ok-conditions", "bootstrapped,synced,head_fresh,min_peers", "Conditions tezos_node_ok is computed from")
+	okMaxHeadAge := flag.Duration("ok-max-head-age", 2*time.Minute, "Maximum head block age for the head_fresh condition")
+	okMinPeers := flag.Int("ok-min-peers", 1, "Minimum number of connections for the min_peers condition")
+	perPeerMetrics := flag.Bool("per-peer-metrics", false, "Report per peer metrics")
+	perPeerLimit := flag.Int("per-peer-metrics-limit", 100, "Maximum number of peers to report per peer metrics for")
+	networkCacheTTL := flag.Duration("network-cache-ttl", 0, "Time to keep peers and points lists between scrapes, use /metrics?refresh=peers,points to bypass")
+	maxOpKinds := flag.Int("max-operation-kinds", 64, "Maximum number of distinct operation kind label values, the rest are reported as \"other\". Zero disables the limit")
+	churnMetrics := flag.Bool("churn-metrics", false, "Count connection events by monitoring running peers and points logs")
+	churnMaxStreams := flag.Int("churn-max-streams", 100, "Maximum number of peers and points log streams to monitor at once")
+	churnRefreshInterval := flag.Duration("churn-refresh-interval", time.Minute, "Running peers and points list refresh interval")
+	resolvePoints := flag.Bool("resolve-point-hostnames", false, "Resolve points known by host names to report their address family")
+	meshNodes := flag.String("mesh-nodes", "", "Comma separated RPC URLs of the other nodes of a private mesh to report the node's connections to them")
+	workerMetrics := flag.Bool("worker-metrics", false, "Report the state and request queues of the node validator workers, requires access to /workers RPCs")
+	trustedPointMetrics := flag.Bool("trusted-point-metrics", false, "Report per point state and last connection times of the trusted points")
+	peerStates := flag.String("peer-states", "", "Comma separated list of peer states to enumerate (accepted, running, disconnected). Empty list enumerates all peers")
+	pointStates := flag.String("point-states", "", "Comma separated list of point states to enumerate (requested, accepted, running, disconnected). Empty list enumerates all points")
+	lightNetwork := flag.Bool("light-network", false, "Don't enumerate peers and points, derive network metrics from the connections list only")
+	rpcMaxAttempts := flag.Int("rpc-max-attempts", 1, "Maximum number of attempts of RPC requests failed with a connection error or a 5xx status. 1 disables retries")
+	rpcRetryBackoff := flag.Duration("rpc-retry-backoff", 200*time.Millisecond, "Delay before the first RPC retry, doubled after every next attempt")
+	rpcRetryMaxBackoff := flag.Duration("rpc-retry-max-backoff", 2*time.Second, "Maximum delay between RPC retries")
+	rpcCacheTTL := flag.Duration("rpc-cache-ttl", 0, "Time to keep responses of the RPCs given by -rpc-cache-paths, shared by all scrapes. Zero disables the cache, use /metrics?refresh=all to bypass")
+	rpcCachePaths := flag.String("rpc-cache-paths", "/network/peers,/network/points,/chains/*/blocks/*/context/constants,/chains/*/blocks/*/context/delegates", "Comma separated RPC path patterns cached along with their subpaths, * matches a single path segment")
+	rpcMetrics := flag.Bool("rpc-metrics", true, "Export duration histograms and error counters of all RPC requests by endpoint")
+	slowRPCThreshold := flag.Duration("slow-rpc-threshold", 5*time.Second, "Log and count RPC requests taking longer than the threshold. Zero disables")
+	debugDumps := flag.Int("debug-dumps", 0, "Number of last RPC request/response dumps to retain and serve at /debug/dumps. Zero disables")
+	debugDumpsToken := flag.String("debug-dumps-token", "", "Bearer token required to access /debug/dumps")
+	strictDecode := flag.Bool("strict-decode", false, "Fail on RPC response fields unknown to the exporter")
+	detectUnknownFields := flag.Bool("detect-unknown-fields", false, "Count RPC responses containing unknown fields (decodes responses twice)")
+	headSource := flag.String("head-source", "stream", "Chain head source for the head derived collectors: \"stream\" (/monitor/heads) or \"poll\" (head header polling)")
+	blockMetadata := flag.String("block-metadata", "", "Head block metadata mode supported by newer nodes: \"never\" (much cheaper, disables metadata based metrics), \"always\" or \"force\" (including too large metadata). Empty keeps the node default")
+	headPollInterval := flag.Duration("head-poll-interval", 5*time.Second, "Chain head polling interval")
+	streamTransport := flag.String("stream-transport", "auto", "Monitor RPC stream format: \"chunked\" (chunked JSON), \"sse\" (server-sent events) or \"auto\" (by response content type)")
+	rateWindow := flag.Duration("rate-window", 5*time.Minute, "Sliding window for the in-process per minute block and operation rates")
+	cycleRetention := flag.Int("cycle-retention", 5, "Number of last cycles to keep per cycle counters for")
+	bakers := flag.String("bakers", "", "Comma separated list of watched baker addresses")
+	depositAddresses := flag.String("deposit-addresses", "", "Comma separated list of addresses to count incoming transactions to")
+	watchToken := flag.String("watch-token", "", "Bearer token required to register operations for confirmation tracking at /watch/operations. Empty disables")
+	maintenanceToken := flag.String("maintenance-token", "", "Bearer token required to set planned maintenance windows at /maintenance reported by tezos_exporter_maintenance. Empty disables")
+	watchDepth := flag.Int("watch-depth", 30, "Confirmation depth after which watched operations are no longer tracked")
+	expectedConnections := flag.Int("expected-connections", 0, "The node's expected connections number as given to its --connections option. Used to report connection targets instead of the node configuration")
+	metricsLabels := flag.String("metrics-labels", "", "Comma separated list of name=value labels added to all exported metrics")
+	metricsPrefix := flag.String("metrics-prefix", "", "Prefix prepended to all exported metric names")
+	alternateBlocks := flag.Bool("alternate-blocks", false, "Count validated blocks which never became head by monitoring the valid blocks stream")
+	balanceAddresses := flag.String("balance-addresses", "", "Comma separated list of implicit account addresses to report balances for")
+	testChain := flag.Bool("test-chain", false, "Monitor the mempool and heads of the test chain while it's running")
+	preset := flag.String("preset", os.Getenv("TEZOS_EXPORTER_PRESET"), "Flag defaults for a common deployment: \"docker\", \"baker\" or \"public-rpc\". Flags given explicitly take precedence. Defaults to TEZOS_EXPORTER_PRESET environment variable")
+	quitEndpoint := flag.Bool("enable-quit-endpoint", false, "Enable /-/quit triggering a graceful shutdown on POST or PUT, e.g. from a preStop hook")
+	quitToken := flag.String("quit-token", "", "Bearer token required by /-/quit")
+	startupDelay := flag.Duration("startup-delay", 0, "Delay before starting the stream monitors, scrape time node metrics are omitted until then")
+	waitForNode := flag.Bool("wait-for-node", false, "Don't start the stream monitors until the node answers RPC requests, scrape time node metrics are omitted until then")
+	noAutoMaxProcs := flag.Bool("disable-automaxprocs", false, "Don't set GOMAXPROCS according to the container CPU quota")
 
 	flag.Parse()
 
+	if *preset != "" {
+		if err := applyPreset(*preset); err != nil {
+			log.WithError(err).Error("error applying preset")
+			os.Exit(1)
+		}
+	}
+
+	if !*noAutoMaxProcs {
+		if _, err := maxprocs.Set(maxprocs.Logger(log.Infof)); err != nil {
+			log.WithError(err).Error("error setting GOMAXPROCS")
+		}
+	}
+
+	if *gcPercent != 0 {
+		debug.SetGCPercent(*gcPercent)
+	}
+	if *memoryLimit > 0 {
+		debug.SetMemoryLimit(*memoryLimit)
+	}
+
+	if *octezConfigPath != "" {
+		var urlSet bool
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "tezos-node-url" {
+				urlSet = true
+			}
+		})
+
+		if !urlSet {
+			u, err := rpcURLFromOctezConfig(*octezConfigPath)
+			if err != nil {
+				log.WithError(err).Error("error reading node configuration")
+				os.Exit(1)
+			}
+			*tezosAddr = u
+			log.WithField("url", u).Info("using RPC endpoint from node configuration")
+		}
+	}
+
 	client, err := tezos.NewRPCClient(*tezosAddr)
 	if err != nil {
 		log.WithError(err).Error("error initializing Tezos RPC client")
 		os.Exit(1)
 	}
 
+	if *rpcUsername != "" {
+		client.Username = *rpcUsername
+		if *rpcPasswordFile != "" {
+			if client.Password, err = readSecret(*rpcPasswordFile); err != nil {
+				log.WithError(err).Error("error reading node RPC password")
+				os.Exit(1)
+			}
+		}
+	}
+	if *rpcTokenFile != "" {
+		if client.BearerToken, err = readSecret(*rpcTokenFile); err != nil {
+			log.WithError(err).Error("error reading node RPC bearer token")
+			os.Exit(1)
+		}
+	}
+
+	if *tlsCAFile != "" || *tlsCertFile != "" || *tlsKeyFile != "" || *tlsInsecure {
+		if strings.HasPrefix(*tezosAddr, "unix:") {
+			log.WithField("url", *tezosAddr).Error("TLS options don't apply to Unix domain sockets")
+			os.Exit(1)
+		}
+		opts := tezos.TLSOptions{
+			CAFile:             *tlsCAFile,
+			CertFile:           *tlsCertFile,
+			KeyFile:            *tlsKeyFile,
+			InsecureSkipVerify: *tlsInsecure,
+		}
+		cfg, err := opts.Config()
+		if err != nil {
+			log.WithError(err).Error("error loading TLS configuration")
+			os.Exit(1)
+		}
+		client.Transport = tezos.NewTransport(cfg)
+	}
+
+	// baseTransport returns the transport the wrappers are built upon making it customizable
+	baseTransport := func() *http.Transport {
+		t, ok := client.Transport.(*http.Transport)
+		if !ok {
+			t = tezos.NewTransport(nil)
+			client.Transport = t
+		}
+		return t
+	}
+
+	if *rpcProxy != "" {
+		if strings.HasPrefix(*tezosAddr, "unix:") {
+			log.WithField("url", *tezosAddr).Error("proxy doesn't apply to Unix domain sockets")
+			os.Exit(1)
+		}
+		proxy, err := tezos.ProxyFunc(*rpcProxy)
+		if err != nil {
+			log.WithError(err).Error("error parsing proxy URL")
+			os.Exit(1)
+		}
+		baseTransport().Proxy = proxy
+	}
+	if !*rpcCompression {
+		baseTransport().DisableCompression = true
+	}
+	// The transport is shared by all collectors so the pool limits apply to the exporter as a whole
+	pool := baseTransport()
+	pool.MaxIdleConnsPerHost = *rpcMaxIdleConns
+	if pool.MaxIdleConns != 0 && pool.MaxIdleConns < *rpcMaxIdleConns {
+		pool.MaxIdleConns = *rpcMaxIdleConns
+	}
+	pool.IdleConnTimeout = *rpcIdleConnTimeout
+	pool.TLSHandshakeTimeout = *rpcTLSHandshakeTimeout
+
+	if *rateWindow <= 0 {
+		log.WithField("window", *rateWindow).Error("rate window must be positive")
+		os.Exit(1)
+	}
+
+	if *nodeDataDir != "" && *storageSizeInterval <= 0 {
+		log.WithField("interval", *storageSizeInterval).Error("storage size interval must be positive")
+		os.Exit(1)
+	}
+
+	if *quitEndpoint && *quitToken == "" {
+		log.Error("the quit endpoint requires an access token")
+		os.Exit(1)
+	}
+
+	if *debugDumps > 0 {
+		if *debugDumpsToken == "" {
+			log.Error("debug dumps require an access token")
+			os.Exit(1)
+		}
+		client.Dumps = tezos.NewDumpBuffer(*debugDumps)
+	}
+
+	switch *streamTransport {
+	case "auto":
+	case "chunked":
+		client.Stream = tezos.ChunkedJSONStream{}
+	case "sse":
+		client.Stream = tezos.SSEStream{}
+	default:
+		log.WithField("transport", *streamTransport).Error("unknown stream transport")
+		os.Exit(1)
+	}
+
+	client.StrictDecode = *strictDecode
+	var unknownFields *collector.UnknownFieldsCollector
+	if *detectUnknownFields && !*strictDecode {
+		unknownFields = collector.NewUnknownFieldsCollector()
+		client.UnknownFieldHandler = unknownFields.HandleUnknownField
+	}
+
+	var retries *collector.RetriesCollector
+	if *rpcMaxAttempts > 1 {
+		client.Retry = &tezos.RetryPolicy{
+			MaxAttempts: *rpcMaxAttempts,
+			Backoff:     *rpcRetryBackoff,
+			MaxBackoff:  *rpcRetryMaxBackoff,
+		}
+		retries = collector.NewRetriesCollector()
+		client.RetryHandler = retries.HandleRetry
+	}
+
+	var rpcCache *collector.RPCCacheCollector
+	if *rpcCacheTTL > 0 {
+		client.Cache = tezos.NewResponseCache(*rpcCacheTTL, strings.Split(*rpcCachePaths, ","))
+		rpcCache = collector.NewRPCCacheCollector()
+		client.CacheHandler = rpcCache.HandleCache
+	}
+
+	var rpcRequests *collector.RPCMetricsCollector
+	if *rpcMetrics {
+		rpcRequests = collector.NewRPCMetricsCollector(client.Transport)
+		client.Transport = rpcRequests
+	}
+
+	var slowRequests *collector.SlowRequestsCollector
+	if *slowRPCThreshold > 0 {
+		slowRequests = collector.NewSlowRequestsCollector(client.Transport, *slowRPCThreshold)
+		client.Transport = slowRequests
+	}
+
 	service := &tezos.Service{Client: client}
 
-	reg := prometheus.NewRegistry()
+	guard := collector.NewMemoryGuard(*softMemoryLimit)
+	var poll time.Duration
+	switch *headSource {
+	case "stream":
+	case "poll":
+		poll = *headPollInterval
+	default:
+		log.WithField("source", *headSource).Error("unknown head source")
+		os.Exit(1)
+	}
+	var blockOpts *tezos.BlockOptions
+	switch *blockMetadata {
+	case "":
+	case tezos.MetadataAlways, tezos.MetadataNever:
+		blockOpts = &tezos.BlockOptions{Metadata: *blockMetadata}
+	case "force":
+		blockOpts = &tezos.BlockOptions{Metadata: tezos.MetadataAlways, ForceMetadata: true}
+	default:
+		log.WithField("mode", *blockMetadata).Error("unknown block metadata mode")
+		os.Exit(1)
+	}
+	var gate *collector.StartupGate
+	if *startupDelay > 0 || *waitForNode {
+		gate = collector.NewStartupGate(service, *chainID, *startupDelay, *waitForNode, *rpcTimeout, *headRetryInterval)
+	}
+	follower := collector.NewBlockFollower(service, *chainID, *rpcTimeout, *headRetryInterval, poll, guard, blockOpts)
+
+	rollup, err := collector.NewRollupCollector(service, *rpcTimeout, *chainID, follower, strings.Split(*okConditions, ","), *okMaxHeadAge, *okMinPeers)
+	if err != nil {
+		log.WithError(err).Error("error initializing health rollup")
+		os.Exit(1)
+	}
+
+	if !*perPeerMetrics {
+		*perPeerLimit = 0
+	}
+
+	var labels prometheus.Labels
+	if *metricsLabels != "" {
+		if labels, err = parseLabels(*metricsLabels); err != nil {
+			log.WithError(err).Error("error parsing metrics labels")
+			os.Exit(1)
+		}
+	}
+	wrap := func(reg prometheus.Registerer) prometheus.Registerer {
+		if labels != nil {
+			reg = prometheus.WrapRegistererWith(labels, reg)
+		}
+		if *metricsPrefix != "" {
+			reg = prometheus.WrapRegistererWithPrefix(*metricsPrefix, reg)
+		}
+		return reg
+	}
+	registry := prometheus.NewRegistry()
+	reg := wrap(registry)
 	reg.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
 	reg.MustRegister(prometheus.NewGoCollector())
 	reg.MustRegister(collector.NewBuildInfoCollector(""))
-	reg.MustRegister(collector.NewNetworkCollector(service, *rpcTimeout, *chainID))
-	reg.MustRegister(collector.NewMempoolOperationsCollectorCollector(service, *chainID, strings.Split(*pools, ","), *mempoolRetryInterval))
+	reg.MustRegister(collector.NewConfigInfoCollector(configSettings()))
+	networkCache := collector.NewTTLCache(*networkCacheTTL)
+	restarts := collector.NewRestartDetector()
+	reg.MustRegister(restarts)
+	if gate != nil {
+		reg.MustRegister(gate)
+	}
+	reg.MustRegister(collector.Gated(gate, collector.NewNetworkCollector(service, *rpcTimeout, *chainID, guard, *perPeerLimit, networkCache, strings.Split(*peerStates, ","), strings.Split(*pointStates, ","), *lightNetwork, restarts, *trustedPointMetrics, *resolvePoints)))
+	reg.MustRegister(collector.Gated(gate, collector.NewMempoolPendingCollector(service, *rpcTimeout, *chainID)))
+	reg.MustRegister(collector.Gated(gate, collector.NewInvalidBlocksCollector(service, *rpcTimeout, *chainID, *invalidBlockInfo)))
+	kinds := collector.NewKindLimiter(*maxOpKinds)
+	unknownKinds := collector.NewUnknownOperationKindsCollector(kinds)
+	reg.MustRegister(unknownKinds)
+	mempool := collector.NewMempoolOperationsCollectorCollector(context.Background(), service, *chainID, strings.Split(*pools, ","), *mempoolRetryInterval, *mempoolExplicitQuery, *mempoolSingleStream, kinds, unknownKinds, gate)
+	reg.MustRegister(mempool)
+	reg.MustRegister(collector.Gated(gate, collector.NewNodeStatsCollector(service, *rpcTimeout, restarts)))
+	if *meshNodes != "" {
+		mesh := make(map[string]*tezos.Service)
+		for _, addr := range strings.Split(*meshNodes, ",") {
+			u, err := url.Parse(addr)
+			if err != nil {
+				log.WithError(err).Error("error parsing mesh node URL")
+				os.Exit(1)
+			}
+			c, err := tezos.NewRPCClient(addr)
+			if err != nil {
+				log.WithError(err).Error("error initializing mesh node RPC client")
+				os.Exit(1)
+			}
+			name := u.Host
+			if u.Scheme == "unix" {
+				name = u.Path
+			} else if !strings.HasPrefix(*tezosAddr, "unix:") {
+				// Share TLS settings, the connection pool and RPC metrics with the monitored node
+				c.Transport = client.Transport
+			}
+			mesh[name] = &tezos.Service{Client: c}
+		}
+		reg.MustRegister(collector.Gated(gate, collector.NewMeshCollector(service, *rpcTimeout, mesh)))
+	}
+	lag := collector.NewStreamLagCollector(follower)
+	reg.MustRegister(lag)
+	reg.MustRegister(collector.NewProtocolCollector(follower))
+	reg.MustRegister(collector.NewConstantsCollector(follower))
+	reg.MustRegister(collector.NewBlockMetadataCollector(follower))
+	reg.MustRegister(collector.NewVotingCollector(follower))
+	reg.MustRegister(collector.NewEndorsementLatencyCollector(follower, mempool))
+	reg.MustRegister(collector.NewEndorsementPowerCollector(follower))
+	reg.MustRegister(collector.NewBlockSizeCollector(follower))
+	reg.MustRegister(collector.NewBlockRoundCollector(follower))
+	reg.MustRegister(collector.NewBlockOperationsCollector(follower, kinds, unknownKinds))
+	reg.MustRegister(collector.NewRateCollector(follower, *rateWindow, kinds))
+	reg.MustRegister(collector.NewCycleCollector(follower, *cycleRetention))
+	var targets *collector.ConnectionTargets
+	if *expectedConnections > 0 {
+		targets = collector.ConnectionTargetsFromExpected(*expectedConnections)
+	} else if *octezConfigPath != "" {
+		if targets, err = connectionTargetsFromOctezConfig(*octezConfigPath); err != nil {
+			log.WithError(err).Error("error reading node configuration")
+			os.Exit(1)
+		}
+	}
+	if targets != nil {
+		reg.MustRegister(collector.NewConnectionTargetsCollector(targets))
+	}
+	var bakerList []string
+	var nextSlots *collector.NextSlotCollector
+	if *bakers != "" {
+		bakerList = strings.Split(*bakers, ",")
+		reg.MustRegister(collector.Gated(gate, collector.NewPayoutCollector(service, *rpcTimeout, *chainID, follower, bakerList, *cycleRetention)))
+		reg.MustRegister(collector.NewRewardsCollector(service, *rpcTimeout, *chainID, follower, bakerList, *cycleRetention))
+		reg.MustRegister(collector.NewLastBakedCollector(follower, bakerList))
+		nextSlots = collector.NewNextSlotCollector(service, *rpcTimeout, *chainID, follower, bakerList)
+		reg.MustRegister(nextSlots)
+	}
+	reg.MustRegister(collector.NewEvidenceCollector(follower, mempool, bakerList))
+	if *depositAddresses != "" {
+		deposits, err := collector.NewDepositCollector(follower, strings.Split(*depositAddresses, ","))
+		if err != nil {
+			log.WithError(err).Error("error initializing deposit watcher")
+			os.Exit(1)
+		}
+		reg.MustRegister(deposits)
+	}
+	if *balanceAddresses != "" {
+		balances, err := collector.NewBalanceCollector(service, *rpcTimeout, *chainID, strings.Split(*balanceAddresses, ","))
+		if err != nil {
+			log.WithError(err).Error("error initializing balance watcher")
+			os.Exit(1)
+		}
+		reg.MustRegister(collector.Gated(gate, balances))
+	}
+	var confirmations *collector.ConfirmationCollector
+	if *watchToken != "" {
+		confirmations = collector.NewConfirmationCollector(follower, *watchDepth)
+		reg.MustRegister(confirmations)
+	}
+	var maintenance *collector.MaintenanceCollector
+	if *maintenanceToken != "" {
+		maintenance = collector.NewMaintenanceCollector()
+		reg.MustRegister(maintenance)
+	}
+	if *alternateBlocks {
+		reg.MustRegister(collector.NewValidBlocksCollector(service, *chainID, *headRetryInterval, follower, lag, gate))
+	}
+	reg.MustRegister(collector.Gated(gate, collector.NewConsistencyCollector(service, *rpcTimeout, *chainID, follower)))
+	reg.MustRegister(collector.Gated(gate, collector.NewChainCollector(service, *rpcTimeout, *chainID)))
+	reg.MustRegister(collector.Gated(gate, collector.NewEarliestBlockCollector(service, *rpcTimeout, *chainID)))
+	if *workerMetrics {
+		reg.MustRegister(collector.Gated(gate, collector.NewWorkersCollector(service, *rpcTimeout, *chainID)))
+	}
+	reg.MustRegister(collector.Gated(gate, rollup))
+	if rpcRequests != nil {
+		reg.MustRegister(rpcRequests)
+	}
+	if rpcCache != nil {
+		reg.MustRegister(rpcCache)
+	}
+	if slowRequests != nil {
+		reg.MustRegister(slowRequests)
+	}
+	if unknownFields != nil {
+		reg.MustRegister(unknownFields)
+	}
+	if retries != nil {
+		reg.MustRegister(retries)
+	}
+	if *churnMetrics {
+		reg.MustRegister(collector.NewChurnCollector(service, *rpcTimeout, *churnRefreshInterval, *churnMaxStreams, gate))
+	}
+	if *nodeDataDir != "" {
+		reg.MustRegister(collector.NewDataDirCollector(*nodeDataDir, *storageSizeInterval))
+	}
+	if *nodePidFile != "" || *nodeProcessName != "" {
+		reg.MustRegister(collector.NewNodeProcessCollector(*nodePidFile, *nodeProcessName))
+	}
 
-	http.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	gatherers := prometheus.Gatherers{registry}
+	if *testChain {
+		testChainRegistry := prometheus.NewRegistry()
+		follower.Subscribe((&TestChainMonitor{
+			service:  service,
+			registry: testChainRegistry,
+			wrap:     wrap,
+			pools:    strings.Split(*pools, ","),
+			explicit: *mempoolExplicitQuery,
+			single:   *mempoolSingleStream,
+			timeout:  *rpcTimeout,
+			interval: *headRetryInterval,
+			poll:     poll,
+			guard:    guard,
+			opts:     blockOpts,
+			kinds:    kinds,
+			unknown:  unknownKinds,
+		}).handleBlock)
+		gatherers = append(gatherers, testChainRegistry)
+	}
+
+	go func() {
+		gate.Wait()
+		follower.Start(context.Background())
+	}()
+
+	gatherer := NewUpGatherer(gatherers, gate, *metricsPrefix, wrap)
+	http.Handle("/metrics", &RefreshHandler{
+		cache:    networkCache,
+		rpcCache: client.Cache,
+		handler:  promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}),
+	})
 	if !*noHealthEp {
-		http.Handle("/health", NewHealthHandler(service, *chainID, *isBootstrappedPollInterval, *isBootstrappedThreshold))
+		health := NewHealthHandler(service, *chainID, *rpcTimeout, *isBootstrappedPollInterval, *isBootstrappedThreshold, reg)
+		http.Handle("/health", health)
+		http.Handle("/health/history", &HistoryHandler{history: health.history})
+	}
+	http.Handle("/status", &StatusHandler{
+		service: service,
+		chainID: *chainID,
+		timeout: *rpcTimeout,
+	})
+	if client.Dumps != nil {
+		http.Handle("/debug/dumps", &DumpsHandler{
+			dumps: client.Dumps,
+			token: *debugDumpsToken,
+		})
+	}
+	if confirmations != nil {
+		http.Handle("/watch/operations", &WatchHandler{
+			confirmations: confirmations,
+			token:         *watchToken,
+		})
+	}
+	if nextSlots != nil {
+		http.Handle("/maintenance-windows", &MaintenanceWindowsHandler{slots: nextSlots})
+	}
+	if maintenance != nil {
+		http.Handle("/maintenance", &MaintenanceHandler{
+			maintenance: maintenance,
+			token:       *maintenanceToken,
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if *quitEndpoint {
+		http.Handle("/-/quit", &QuitHandler{
+			cancel: cancel,
+			token:  *quitToken,
+		})
 	}
 
 	log.WithField("address", *metricsAddr).Info("tezos_exporter starting...")
 
-	if err := http.ListenAndServe(*metricsAddr, nil); err != nil {
+	srv := &http.Server{Addr: *metricsAddr}
+	if soak != nil {
+		go func() {
+			if err := srv.ListenAndServe(); err != nil {
+				log.WithError(err).Error("error starting webserver")
+			}
+		}()
+		soak.prefix = *metricsPrefix
+		if err := soak.Run(gatherer); err != nil {
+			log.WithError(err).Error("soak test failed")
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := run(ctx, srv); err != nil {
 		log.WithError(err).Error("error starting webserver")
 		os.Exit(1)
 	}
+
+	log.Info("tezos_exporter stopped")
 }
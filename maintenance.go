@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/collector"
+	log "github.com/sirupsen/logrus"
+)
+
+// MaintenanceHandler manages the planned maintenance window reported by MaintenanceCollector for clients presenting the bearer token.
+// GET returns the end of the current window, PUT and POST start one ending at the until (RFC 3339) or after the duration query parameter,
+// DELETE ends it.
+type MaintenanceHandler struct {
+	maintenance *collector.MaintenanceCollector
+	token       string
+}
+
+type maintenanceWindow struct {
+	Until *time.Time `json:"until"`
+}
+
+func (h *MaintenanceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !authorized(w, r, h.token) {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		var res maintenanceWindow
+		if until := h.maintenance.Until(); !until.IsZero() {
+			res.Until = &until
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&res)
+
+	case http.MethodPut, http.MethodPost:
+		var until time.Time
+		q := r.URL.Query()
+		switch {
+		case q.Get("until") != "":
+			t, err := time.Parse(time.RFC3339, q.Get("until"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			until = t
+		case q.Get("duration") != "":
+			d, err := time.ParseDuration(q.Get("duration"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			until = time.Now().Add(d)
+		default:
+			http.Error(w, "missing until or duration", http.StatusBadRequest)
+			return
+		}
+		if !until.After(time.Now()) {
+			http.Error(w, "maintenance window is in the past", http.StatusBadRequest)
+			return
+		}
+		h.maintenance.Set(until)
+		log.WithFields(log.Fields{"until": until, "remote": r.RemoteAddr}).Info("maintenance window set")
+		w.WriteHeader(http.StatusNoContent)
+
+	case http.MethodDelete:
+		h.maintenance.Set(time.Time{})
+		log.WithField("remote", r.RemoteAddr).Info("maintenance window cleared")
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST, DELETE")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/collector"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceHandler(t *testing.T) {
+	until := time.Now().Add(time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	// The requests are served in order by the same handler
+	tests := []struct {
+		name   string
+		method string
+		query  string
+		auth   string
+		status int
+		body   string
+		active bool
+	}{
+		{name: "no token", method: http.MethodGet, status: http.StatusUnauthorized},
+		{name: "wrong token", method: http.MethodGet, auth: "Bearer wrong", status: http.StatusUnauthorized},
+		{name: "no window", method: http.MethodGet, auth: "Bearer secret", status: http.StatusOK, body: `{"until":null}`},
+		{name: "missing end", method: http.MethodPut, auth: "Bearer secret", status: http.StatusBadRequest},
+		{name: "malformed until", method: http.MethodPut, query: "until=tomorrow", auth: "Bearer secret", status: http.StatusBadRequest},
+		{name: "malformed duration", method: http.MethodPut, query: "duration=1", auth: "Bearer secret", status: http.StatusBadRequest},
+		{name: "past", method: http.MethodPut, query: "until=" + past, auth: "Bearer secret", status: http.StatusBadRequest},
+		{name: "until", method: http.MethodPut, query: "until=" + until, auth: "Bearer secret", status: http.StatusNoContent, active: true},
+		{name: "window", method: http.MethodGet, auth: "Bearer secret", status: http.StatusOK, body: `{"until":"` + until + `"}`, active: true},
+		{name: "delete", method: http.MethodDelete, auth: "Bearer secret", status: http.StatusNoContent},
+		{name: "duration", method: http.MethodPost, query: "duration=30m", auth: "Bearer secret", status: http.StatusNoContent, active: true},
+		{name: "method", method: http.MethodPatch, auth: "Bearer secret", status: http.StatusMethodNotAllowed, active: true},
+	}
+
+	maintenance := collector.NewMaintenanceCollector()
+	h := &MaintenanceHandler{maintenance: maintenance, token: "secret"}
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "/maintenance?"+test.query, nil)
+		if test.auth != "" {
+			req.Header.Set("Authorization", test.auth)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		require.Equal(t, test.status, w.Code, test.name)
+		if test.body != "" {
+			require.JSONEq(t, test.body, w.Body.String(), test.name)
+		}
+		require.Equal(t, test.active, !maintenance.Until().IsZero(), test.name)
+	}
+
+	maintenance.Set(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, testutil.CollectAndCompare(maintenance, strings.NewReader(`
+# HELP tezos_exporter_maintenance Set to 1 during the planned maintenance window ending at the until label, 0 otherwise. Intended for inhibiting alerts during planned node restarts.
+# TYPE tezos_exporter_maintenance gauge
+tezos_exporter_maintenance{until="2030-01-02T03:04:05Z"} 1
+`)))
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/collector"
+)
+
+// MaintenanceWindowsHandler lists the upcoming spans without baking slots of any of the watched bakers
+// not shorter than the optional min_duration query parameter. 503 is returned until the rights are known.
+type MaintenanceWindowsHandler struct {
+	slots *collector.NextSlotCollector
+}
+
+func (h *MaintenanceWindowsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var min time.Duration
+	if v := r.URL.Query().Get("min_duration"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		min = d
+	}
+
+	gaps := h.slots.Gaps(min)
+	if gaps == nil {
+		http.Error(w, "baking rights aren't known yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(gaps)
+}
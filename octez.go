@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+
+	"github.com/ecadlabs/tezos_exporter/collector"
+)
+
+const defaultRPCPort = "8732"
+
+// Node defaults for the p2p limits
+const (
+	defaultMinConnections      = 10
+	defaultExpectedConnections = 50
+	defaultMaxConnections      = 100
+)
+
+// octezConfig is a subset of the node's config.json
+type octezConfig struct {
+	RPC struct {
+		ListenAddr  string   `json:"listen-addr"`
+		ListenAddrs []string `json:"listen-addrs"`
+		Cert        string   `json:"crt"`
+	} `json:"rpc"`
+	P2P struct {
+		Limits struct {
+			MinConnections      *int `json:"min-connections"`
+			ExpectedConnections *int `json:"expected-connections"`
+			MaxConnections      *int `json:"max-connections"`
+		} `json:"limits"`
+	} `json:"p2p"`
+}
+
+func readOctezConfig(path string) (*octezConfig, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var conf octezConfig
+	if err := json.Unmarshal(buf, &conf); err != nil {
+		return nil, err
+	}
+	return &conf, nil
+}
+
+// rpcURLFromOctezConfig returns URL of the RPC endpoint declared in the node's config file
+func rpcURLFromOctezConfig(path string) (string, error) {
+	conf, err := readOctezConfig(path)
+	if err != nil {
+		return "", err
+	}
+
+	addr := conf.RPC.ListenAddr
+	if len(conf.RPC.ListenAddrs) != 0 {
+		addr = conf.RPC.ListenAddrs[0]
+	}
+	if addr == "" {
+		return "", errors.New("RPC listen address is not specified in the node configuration")
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		// Port is optional
+		host, port = addr, defaultRPCPort
+	}
+
+	if ip := net.ParseIP(host); host == "" || ip != nil && ip.IsUnspecified() {
+		host = "localhost"
+	}
+
+	scheme := "http"
+	if conf.RPC.Cert != "" {
+		scheme = "https"
+	}
+
+	return scheme + "://" + net.JoinHostPort(host, port), nil
+}
+
+// connectionTargetsFromOctezConfig returns the connection limits declared in the node's config file, falling back to the node defaults
+func connectionTargetsFromOctezConfig(path string) (*collector.ConnectionTargets, error) {
+	conf, err := readOctezConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := collector.ConnectionTargets{
+		Min:      defaultMinConnections,
+		Expected: defaultExpectedConnections,
+		Max:      defaultMaxConnections,
+	}
+	limits := &conf.P2P.Limits
+	if limits.MinConnections != nil {
+		targets.Min = *limits.MinConnections
+	}
+	if limits.ExpectedConnections != nil {
+		targets.Expected = *limits.ExpectedConnections
+	}
+	if limits.MaxConnections != nil {
+		targets.Max = *limits.MaxConnections
+	}
+	return &targets, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// presets maps deployment shapes to flag defaults. Flags given on the command line take precedence.
+var presets = map[string]map[string]string{
+	// The exporter runs in a container next to the node container which may be restarted independently
+	"docker": {
+		"head-retry-delay":    "5s",
+		"mempool-retry-delay": "5s",
+		"rpc-timeout":         "30s",
+	},
+	// The node serves a baker, so missed blocks and weak connectivity matter the most
+	"baker": {
+		"alternate-blocks":   "true",
+		"churn-metrics":      "true",
+		"invalid-block-info": "true",
+		"ok-conditions":      "bootstrapped,synced,head_fresh,min_peers",
+		"ok-max-head-age":    "1m",
+		"ok-min-peers":       "10",
+		"rpc-timeout":        "5s",
+	},
+	// The node serves public RPC traffic, so the exporter must stay cheap on the node
+	"public-rpc": {
+		"light-network":      "true",
+		"mempool-pools":      "applied",
+		"network-cache-ttl":  "30s",
+		"ok-conditions":      "bootstrapped,synced,head_fresh",
+		"slow-rpc-threshold": "2s",
+	},
+}
+
+// cgroupMemoryFraction is the part of the container memory limit the docker preset sets the runtime soft memory limit to
+const cgroupMemoryFraction = 0.9
+
+// cgroupMemoryLimit returns the memory limit of the container or zero if not limited
+func cgroupMemoryLimit() int64 {
+	for _, path := range []string{
+		"/sys/fs/cgroup/memory.max",                   // v2
+		"/sys/fs/cgroup/memory/memory.limit_in_bytes", // v1
+	} {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		v, err := strconv.ParseInt(strings.TrimSpace(string(buf)), 10, 64)
+		// "max" or a huge number means no limit
+		if err != nil || v <= 0 || v >= 1<<62 {
+			return 0
+		}
+		return v
+	}
+	return 0
+}
+
+// applyPreset sets the preset flag values unless given explicitly
+func applyPreset(name string) error {
+	preset, ok := presets[name]
+	if !ok {
+		return fmt.Errorf("unknown preset: %s", name)
+	}
+	values := make(map[string]string, len(preset)+1)
+	for n, v := range preset {
+		values[n] = v
+	}
+
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	if name == "docker" && !set["memory-limit"] {
+		if limit := cgroupMemoryLimit(); limit > 0 {
+			values["memory-limit"] = strconv.FormatInt(int64(float64(limit)*cgroupMemoryFraction), 10)
+		}
+	}
+
+	for n, v := range values {
+		if set[n] {
+			continue
+		}
+		if err := flag.Set(n, v); err != nil {
+			return fmt.Errorf("%s: %w", n, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// withPresetFlags replaces the command line flags with string flags named after the preset values for the test duration
+func withPresetFlags(t *testing.T, args ...string) *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	defined := make(map[string]bool)
+	for _, values := range presets {
+		for name := range values {
+			if !defined[name] {
+				fs.String(name, "", "")
+				defined[name] = true
+			}
+		}
+	}
+	if !defined["memory-limit"] {
+		fs.String("memory-limit", "", "")
+	}
+	require.NoError(t, fs.Parse(args))
+
+	old := flag.CommandLine
+	flag.CommandLine = fs
+	t.Cleanup(func() { flag.CommandLine = old })
+	return fs
+}
+
+func TestApplyPreset(t *testing.T) {
+	for name, values := range presets {
+		t.Run(name, func(t *testing.T) {
+			fs := withPresetFlags(t, "-rpc-timeout=1m")
+			require.NoError(t, applyPreset(name))
+
+			for n, v := range values {
+				if n == "rpc-timeout" {
+					continue
+				}
+				require.Equal(t, v, fs.Lookup(n).Value.String(), n)
+			}
+			// Flags given on the command line take precedence
+			require.Equal(t, "1m", fs.Lookup("rpc-timeout").Value.String())
+		})
+	}
+	// The container memory limit isn't stored in the preset
+	require.NotContains(t, presets["docker"], "memory-limit")
+}
+
+func TestApplyPresetErrors(t *testing.T) {
+	withPresetFlags(t)
+	require.EqualError(t, applyPreset("unknown"), "unknown preset: unknown")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("ok-min-peers", 0, "")
+	flag.CommandLine = fs
+	presets["test"] = map[string]string{"ok-min-peers": "ten"}
+	defer delete(presets, "test")
+	err := applyPreset("test")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ok-min-peers: ")
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// QuitHandler triggers a graceful shutdown on POST or PUT so preStop hooks can drain the exporter.
+// The bearer token is always required.
+type QuitHandler struct {
+	cancel context.CancelFunc
+	token  string
+}
+
+func (h *QuitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !authorized(w, r, h.token) {
+		return
+	}
+
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		w.Header().Set("Allow", "POST, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	log.WithField("remote", r.RemoteAddr).Info("shutdown requested")
+	w.Write([]byte("Requesting termination...\n"))
+	h.cancel()
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuitHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		method   string
+		auth     string
+		status   int
+		canceled bool
+	}{
+		{name: "no token", token: "secret", method: http.MethodPost, status: http.StatusUnauthorized},
+		{name: "wrong token", token: "secret", method: http.MethodPost, auth: "Bearer wrong", status: http.StatusUnauthorized},
+		{name: "correct token", token: "secret", method: http.MethodPost, auth: "Bearer secret", status: http.StatusOK, canceled: true},
+		{name: "correct token, PUT", token: "secret", method: http.MethodPut, auth: "Bearer secret", status: http.StatusOK, canceled: true},
+		{name: "correct token, GET", token: "secret", method: http.MethodGet, auth: "Bearer secret", status: http.StatusMethodNotAllowed},
+		{name: "empty handler token", method: http.MethodPost, auth: "Bearer ", status: http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			h := &QuitHandler{cancel: cancel, token: test.token}
+
+			req := httptest.NewRequest(test.method, "/-/quit", nil)
+			if test.auth != "" {
+				req.Header.Set("Authorization", test.auth)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			require.Equal(t, test.status, w.Code)
+			require.Equal(t, test.canceled, ctx.Err() != nil)
+		})
+	}
+}
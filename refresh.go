@@ -0,0 +1,32 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ecadlabs/tezos_exporter/collector"
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+)
+
+// RefreshHandler drops cached values listed in the refresh query parameter before passing the request to the metrics handler.
+// E.g. /metrics?refresh=peers,points or /metrics?refresh=all which also purges the RPC response cache
+type RefreshHandler struct {
+	cache    *collector.TTLCache
+	rpcCache *tezos.ResponseCache
+	handler  http.Handler
+}
+
+func (h *RefreshHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, v := range r.URL.Query()["refresh"] {
+		for _, key := range strings.Split(v, ",") {
+			key = strings.TrimSpace(key)
+			if key == "all" {
+				h.cache.InvalidateAll()
+				h.rpcCache.Purge()
+			} else if key != "" {
+				h.cache.Invalidate(key)
+			}
+		}
+	}
+	h.handler.ServeHTTP(w, r)
+}
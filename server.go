@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// serve runs the server until the context is cancelled and then shuts it down gracefully
+func serve(ctx context.Context, srv *http.Server) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return srv.Shutdown(ctx)
+}
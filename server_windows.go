@@ -0,0 +1,80 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sys/windows/svc"
+)
+
+const serviceName = "tezos_exporter"
+
+// windowsService handles Windows service control requests
+type windowsService struct {
+	ctx context.Context
+	srv *http.Server
+	err error
+}
+
+// Execute implements svc.Handler
+func (w *windowsService) Execute(args []string, r <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	ctx, cancel := context.WithCancel(w.ctx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(ctx, w.srv)
+	}()
+
+	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+
+	for {
+		select {
+		case c := <-r:
+			switch c.Cmd {
+			case svc.Interrogate:
+				changes <- c.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				log.Info("service stop requested")
+				changes <- svc.Status{State: svc.StopPending}
+				cancel()
+				w.err = <-done
+				return false, 0
+			}
+		case err := <-done:
+			// The server also stops gracefully when the context is cancelled
+			w.err = err
+			if err == nil {
+				return false, 0
+			}
+			return false, 1
+		}
+	}
+}
+
+// run serves until stopped by the service manager, an interrupt or the context cancellation
+func run(ctx context.Context, srv *http.Server) error {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		return err
+	}
+
+	if !isService {
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+
+		return serve(ctx, srv)
+	}
+
+	w := windowsService{ctx: ctx, srv: srv}
+	if err := svc.Run(serviceName, &w); err != nil {
+		return err
+	}
+	return w.err
+}
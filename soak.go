@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultSoakRequired lists the metrics required by the soak test unless given explicitly. The metric name prefix is prepended to them.
+var defaultSoakRequired = []string{"tezos_node_up", "tezos_rpc_failed"}
+
+// SoakTest gathers the metrics periodically while the exporter runs and checks that counters, histogram and summary counts
+// never decrease and that the required metrics are present once the warm up is over.
+type SoakTest struct {
+	duration time.Duration
+	interval time.Duration
+	warmup   time.Duration
+	required []string
+	prefix   string // the metric name prefix of the default required metrics
+
+	last map[string]float64
+}
+
+// NewSoakTest returns a new SoakTest configured by the soak subcommand flags which it registers
+func NewSoakTest() *SoakTest {
+	s := &SoakTest{
+		last: make(map[string]float64),
+	}
+	flag.DurationVar(&s.duration, "duration", time.Hour, "Soak test duration")
+	flag.DurationVar(&s.interval, "interval", 15*time.Second, "Interval of gathering the metrics during the soak test")
+	flag.DurationVar(&s.warmup, "warmup", time.Minute, "Time to wait before checking the required metrics")
+	flag.Func("require", "Metric required to be present during the soak test, may be repeated (default "+strings.Join(defaultSoakRequired, ", ")+")", func(v string) error {
+		s.required = append(s.required, v)
+		return nil
+	})
+	return s
+}
+
+// seriesKey returns the metric name followed by its sorted labels
+func seriesKey(name string, m *dto.Metric) string {
+	labels := make([]string, 0, len(m.Label))
+	for _, l := range m.Label {
+		labels = append(labels, fmt.Sprintf("%s=%q", l.GetName(), l.GetValue()))
+	}
+	sort.Strings(labels)
+	return name + "{" + strings.Join(labels, ",") + "}"
+}
+
+// check returns invariant violations of the gathered metrics
+func (s *SoakTest) check(mfs []*dto.MetricFamily, warm bool) []string {
+	var violations []string
+	present := make(map[string]bool, len(mfs))
+	for _, mf := range mfs {
+		present[mf.GetName()] = len(mf.Metric) != 0
+		for _, m := range mf.Metric {
+			var v float64
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				v = m.GetCounter().GetValue()
+			case dto.MetricType_HISTOGRAM:
+				v = float64(m.GetHistogram().GetSampleCount())
+			case dto.MetricType_SUMMARY:
+				v = float64(m.GetSummary().GetSampleCount())
+			default:
+				continue
+			}
+			key := seriesKey(mf.GetName(), m)
+			if last, ok := s.last[key]; ok && v < last {
+				violations = append(violations, fmt.Sprintf("%s decreased from %g to %g", key, last, v))
+			}
+			s.last[key] = v
+		}
+	}
+
+	if warm {
+		required := s.required
+		if len(required) == 0 {
+			for _, name := range defaultSoakRequired {
+				required = append(required, s.prefix+name)
+			}
+		}
+		for _, name := range required {
+			if !present[name] {
+				violations = append(violations, fmt.Sprintf("%s is missing", name))
+			}
+		}
+	}
+	return violations
+}
+
+// Run gathers the metrics until the test duration elapses. An error is returned on the first round with violations.
+func (s *SoakTest) Run(g prometheus.Gatherer) error {
+	log.WithFields(log.Fields{"duration": s.duration, "interval": s.interval}).Info("starting soak test")
+
+	start := time.Now()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	var rounds int
+	for range ticker.C {
+		mfs, err := g.Gather()
+		if err != nil {
+			return fmt.Errorf("error gathering metrics: %w", err)
+		}
+		rounds++
+
+		if violations := s.check(mfs, time.Since(start) >= s.warmup); len(violations) != 0 {
+			for _, v := range violations {
+				log.WithField("round", rounds).Error(v)
+			}
+			return fmt.Errorf("%d invariant violations", len(violations))
+		}
+
+		if time.Since(start) >= s.duration {
+			break
+		}
+	}
+
+	log.WithFields(log.Fields{"rounds": rounds, "series": len(s.last)}).Info("soak test passed")
+	return nil
+}
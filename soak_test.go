@@ -0,0 +1,89 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+// gatherSoak returns the gathered families of the gauges set to 1 and the counter incremented by inc
+func gatherSoak(t *testing.T, gauges []string, inc float64) []*dto.MetricFamily {
+	reg := prometheus.NewPedanticRegistry()
+	for _, name := range gauges {
+		g := prometheus.NewGauge(prometheus.GaugeOpts{Name: name, Help: name})
+		g.Set(1)
+		reg.MustRegister(g)
+	}
+	c := prometheus.NewCounter(prometheus.CounterOpts{Name: "tezos_exporter_requests_total", Help: "requests"})
+	c.Add(inc)
+	reg.MustRegister(c)
+
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+	return mfs
+}
+
+func TestSoakTestCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		prefix     string
+		required   []string
+		gauges     []string
+		warm       bool
+		violations []string
+	}{
+		{
+			name:   "default",
+			gauges: []string{"tezos_node_up", "tezos_rpc_failed"},
+			warm:   true,
+		},
+		{
+			name:       "default missing",
+			gauges:     []string{"tezos_node_up"},
+			warm:       true,
+			violations: []string{"tezos_rpc_failed is missing"},
+		},
+		{
+			// The required metrics aren't checked during the warm up
+			name: "cold",
+		},
+		{
+			name:   "prefix",
+			prefix: "mainnet_",
+			gauges: []string{"mainnet_tezos_node_up", "mainnet_tezos_rpc_failed"},
+			warm:   true,
+		},
+		{
+			name:       "prefix missing",
+			prefix:     "mainnet_",
+			gauges:     []string{"tezos_node_up", "tezos_rpc_failed"},
+			warm:       true,
+			violations: []string{"mainnet_tezos_node_up is missing", "mainnet_tezos_rpc_failed is missing"},
+		},
+		{
+			name:     "explicit",
+			prefix:   "mainnet_",
+			required: []string{"tezos_node_up"},
+			gauges:   []string{"tezos_node_up"},
+			warm:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &SoakTest{required: test.required, prefix: test.prefix, last: make(map[string]float64)}
+			require.Equal(t, test.violations, s.check(gatherSoak(t, test.gauges, 2), test.warm))
+		})
+	}
+}
+
+func TestSoakTestCheckDecrease(t *testing.T) {
+	s := &SoakTest{required: []string{"tezos_node_up"}, last: make(map[string]float64)}
+	gauges := []string{"tezos_node_up"}
+
+	require.Empty(t, s.check(gatherSoak(t, gauges, 2), true))
+	require.Empty(t, s.check(gatherSoak(t, gauges, 3), true))
+	require.Equal(t, []string{"tezos_exporter_requests_total{} decreased from 3 to 1"}, s.check(gatherSoak(t, gauges, 1), true))
+}
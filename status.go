@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+)
+
+// StatusHandler reports the node status summary as JSON. Failed RPCs are listed in errors, 503 is returned if all of them failed.
+type StatusHandler struct {
+	service *tezos.Service
+	chainID string
+	timeout time.Duration
+}
+
+type statusHead struct {
+	Hash      string    `json:"hash"`
+	Level     int       `json:"level"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type statusResponse struct {
+	Bootstrapped *bool             `json:"bootstrapped,omitempty"`
+	SyncState    tezos.SyncState   `json:"sync_state,omitempty"`
+	Head         *statusHead       `json:"head,omitempty"`
+	Version      string            `json:"version,omitempty"`
+	Connections  int               `json:"connections"`
+	Errors       map[string]string `json:"errors,omitempty"`
+}
+
+func (h *StatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	status, err := h.service.GetChainStatus(ctx, h.chainID)
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(&statusResponse{Errors: map[string]string{"": err.Error()}})
+		return
+	}
+
+	var res statusResponse
+	if status.Bootstrapped != nil {
+		res.Bootstrapped = &status.Bootstrapped.Bootstrapped
+		res.SyncState = status.Bootstrapped.SyncState
+	}
+	if status.Head != nil {
+		res.Head = &statusHead{
+			Hash:      status.Head.Hash,
+			Level:     status.Head.Level,
+			Timestamp: status.Head.Timestamp,
+		}
+	}
+	if status.Version != nil {
+		res.Version = status.Version.Version.String()
+	}
+	res.Connections = status.Connections
+	if len(status.Errors) != 0 {
+		res.Errors = make(map[string]string, len(status.Errors))
+		for path, err := range status.Errors {
+			res.Errors[path] = err.Error()
+		}
+	}
+
+	json.NewEncoder(w).Encode(&res)
+}
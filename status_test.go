@@ -0,0 +1,80 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		routes   map[string]*tezostest.Response
+		status   int
+		expected string
+	}{
+		{
+			name: "all",
+			routes: map[string]*tezostest.Response{
+				"/chains/main/is_bootstrapped":    {Body: []byte(`{"bootstrapped":true,"sync_state":"synced"}`)},
+				"/chains/main/blocks/head/header": tezostest.MustFixture("go-tezos/fixtures/chains/header.json"),
+				"/version":                        tezostest.MustFixture("go-tezos/fixtures/version.json"),
+				"/network/connections":            tezostest.MustFixture("go-tezos/fixtures/network/connections.json"),
+			},
+			status: http.StatusOK,
+			expected: `{
+				"bootstrapped": true,
+				"sync_state": "synced",
+				"head": {"hash": "BLnoArJNPCyYFK2z3Mnomi36Jo3FwrjriJ6hvzgTJGYYDKEkDXm", "level": 219133, "timestamp": "2018-11-27T17:49:57Z"},
+				"version": "20.0~rc1",
+				"connections": 2
+			}`,
+		},
+		{
+			name: "partial",
+			routes: map[string]*tezostest.Response{
+				"/chains/main/is_bootstrapped": {Body: []byte(`{"bootstrapped":false,"sync_state":"unsynced"}`)},
+			},
+			status: http.StatusOK,
+			expected: `{
+				"bootstrapped": false,
+				"sync_state": "unsynced",
+				"connections": 0,
+				"errors": {
+					"/chains/main/blocks/head/header": "tezos: HTTP status 404",
+					"/network/connections": "tezos: HTTP status 404",
+					"/version": "tezos: HTTP status 404"
+				}
+			}`,
+		},
+		{
+			name:   "none",
+			status: http.StatusServiceUnavailable,
+			expected: `{
+				"connections": 0,
+				"errors": {"": "tezos: /chains/main/blocks/head/header: tezos: HTTP status 404; /chains/main/is_bootstrapped: tezos: HTTP status 404; /network/connections: tezos: HTTP status 404; /version: tezos: HTTP status 404"}
+			}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := tezostest.NewServer()
+			defer srv.Close()
+			for pattern, resp := range test.routes {
+				srv.Handle(pattern, resp)
+			}
+
+			h := &StatusHandler{service: newTestService(t, srv), chainID: "main", timeout: time.Second}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+			require.Equal(t, test.status, w.Code)
+			require.JSONEq(t, test.expected, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/collector"
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// TestChainMonitor starts mempool and head monitoring of the test chain once the main chain reports it running.
+// The test chain metrics are registered with a "chain" label in a registry of their own.
+type TestChainMonitor struct {
+	service  *tezos.Service
+	registry *prometheus.Registry
+	wrap     func(prometheus.Registerer) prometheus.Registerer
+	pools    []string
+	explicit bool
+	single   bool
+	timeout  time.Duration
+	interval time.Duration
+	poll     time.Duration
+	guard    *collector.MemoryGuard
+	opts     *tezos.BlockOptions
+	kinds    *collector.KindLimiter
+	unknown  *collector.UnknownOperationKindsCollector
+
+	mtx        sync.Mutex
+	chainID    string
+	reg        prometheus.Registerer // wrapped with the current test chain label
+	collectors []prometheus.Collector
+	cancel     context.CancelFunc // stops the monitors of the current test chain
+}
+
+func (t *TestChainMonitor) handleBlock(block *tezos.Block) {
+	status, ok := block.Metadata.TestChainStatus.(*tezos.RunningTestChainStatus)
+	if !ok {
+		return
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if status.ChainID == t.chainID {
+		return
+	}
+
+	// Stop the monitors of the previous test chain and drop its series
+	if t.cancel != nil {
+		t.cancel()
+	}
+	for _, c := range t.collectors {
+		t.reg.Unregister(c)
+	}
+	t.collectors = nil
+	t.chainID = status.ChainID
+
+	log.WithFields(log.Fields{"chain": status.ChainID, "protocol": status.Protocol}).Info("test chain detected")
+
+	var ctx context.Context
+	ctx, t.cancel = context.WithCancel(context.Background())
+	follower := collector.NewBlockFollower(t.service, status.ChainID, t.timeout, t.interval, t.poll, t.guard, t.opts)
+	mempool := collector.NewMempoolOperationsCollectorCollector(ctx, t.service, status.ChainID, t.pools, t.interval, t.explicit, t.single, t.kinds, t.unknown, nil)
+	t.collectors = []prometheus.Collector{
+		mempool,
+		collector.NewProtocolCollector(follower),
+		collector.NewBlockOperationsCollector(follower, t.kinds, t.unknown),
+	}
+
+	t.reg = t.wrap(prometheus.WrapRegistererWith(prometheus.Labels{"chain": status.ChainID}, t.registry))
+	for _, c := range t.collectors {
+		if err := t.reg.Register(c); err != nil {
+			log.WithError(err).WithField("chain", status.ChainID).Error("error registering test chain collector")
+		}
+	}
+	follower.Start(ctx)
+}
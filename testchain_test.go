@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tezos "github.com/ecadlabs/tezos_exporter/go-tezos"
+	"github.com/ecadlabs/tezos_exporter/go-tezos/tezostest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func testChainBlock(chainID string) *tezos.Block {
+	return &tezos.Block{
+		Metadata: tezos.BlockHeaderMetadata{
+			TestChainStatus: &tezos.RunningTestChainStatus{
+				GenericTestChainStatus: tezos.GenericTestChainStatus{Status: "running"},
+				ChainID:                chainID,
+			},
+		},
+	}
+}
+
+func newTestChainMonitor(t *testing.T, srv *tezostest.Server, poll time.Duration) *TestChainMonitor {
+	client, err := tezos.NewRPCClient(srv.URL)
+	require.NoError(t, err)
+	return &TestChainMonitor{
+		service:  &tezos.Service{Client: client},
+		registry: prometheus.NewRegistry(),
+		wrap:     func(r prometheus.Registerer) prometheus.Registerer { return r },
+		pools:    []string{tezos.PoolApplied},
+		timeout:  time.Second,
+		interval: 10 * time.Millisecond,
+		poll:     poll,
+	}
+}
+
+func TestTestChainMonitorStopsPreviousChain(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	// Failing streams make the monitors retry continuously
+	fail := &tezostest.Response{Status: 500, ContentType: "text/plain", Body: []byte("Internal Server Error")}
+	for _, chain := range []string{"NetXtestA", "NetXtestB"} {
+		srv.Handle("/monitor/heads/"+chain, fail)
+		srv.Handle("/chains/"+chain+"/mempool/monitor_operations", fail)
+	}
+	requests := func(chain string) (int, int) {
+		return srv.Requests("/monitor/heads/" + chain), srv.Requests("/chains/" + chain + "/mempool/monitor_operations")
+	}
+	retrying := func(chain string) func() bool {
+		heads, mempool := requests(chain)
+		return func() bool {
+			h, m := requests(chain)
+			return h >= heads+2 && m >= mempool+2
+		}
+	}
+	stopped := func(chain string) {
+		time.Sleep(50 * time.Millisecond)
+		heads, mempool := requests(chain)
+		time.Sleep(100 * time.Millisecond)
+		h, m := requests(chain)
+		require.Equal(t, heads, h)
+		require.Equal(t, mempool, m)
+	}
+
+	m := newTestChainMonitor(t, srv, 0)
+
+	m.handleBlock(testChainBlock("NetXtestA"))
+	require.Eventually(t, retrying("NetXtestA"), 5*time.Second, 10*time.Millisecond)
+
+	m.handleBlock(testChainBlock("NetXtestB"))
+	stopped("NetXtestA")
+	require.Eventually(t, retrying("NetXtestB"), 5*time.Second, 10*time.Millisecond)
+
+	m.cancel()
+	stopped("NetXtestB")
+}
+
+func TestTestChainMonitorPoll(t *testing.T) {
+	srv := tezostest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/chains/NetXtestA/blocks/head/header", tezostest.MustFixture("go-tezos/fixtures/chains/header.json"))
+	srv.Handle("/chains/NetXtestA/mempool/monitor_operations", &tezostest.Response{Hold: true})
+
+	m := newTestChainMonitor(t, srv, 10*time.Millisecond)
+	m.handleBlock(testChainBlock("NetXtestA"))
+	defer m.cancel()
+	require.Eventually(t, func() bool { return srv.Requests("/chains/NetXtestA/blocks/head/header") >= 2 }, 5*time.Second, 10*time.Millisecond)
+	require.Zero(t, srv.Requests("/monitor/heads/*"))
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/ecadlabs/tezos_exporter/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// UpGatherer reports whether all node RPC calls made during the scrape succeeded judging by the RPC failure gauges.
+// No RPC calls are made until the startup gate opens, so the node isn't reported up until then.
+type UpGatherer struct {
+	gatherer prometheus.Gatherer
+	gate     *collector.StartupGate
+	failed   string // RPC failure gauge name
+	up       prometheus.Gauge
+	registry *prometheus.Registry
+}
+
+// NewUpGatherer returns a new UpGatherer wrapping the gatherer. The up gauge is registered through wrap to get the same
+// labels and prefix as the rest of the metrics.
+func NewUpGatherer(gatherer prometheus.Gatherer, gate *collector.StartupGate, prefix string, wrap func(prometheus.Registerer) prometheus.Registerer) *UpGatherer {
+	g := &UpGatherer{
+		gatherer: gatherer,
+		gate:     gate,
+		failed:   prefix + "tezos_rpc_failed",
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tezos_node",
+			Name:      "up",
+			Help:      "A gauge that is set to 1 when all node RPC calls made during the scrape succeeded, 0 otherwise or before the monitors are started.",
+		}),
+		registry: prometheus.NewRegistry(),
+	}
+	wrap(g.registry).MustRegister(g.up)
+	return g
+}
+
+// Gather implements prometheus.Gatherer
+func (g *UpGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+
+	up := 1.0
+	if !g.gate.Open() {
+		up = 0
+	}
+	for _, f := range families {
+		if f.GetName() != g.failed {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			if m.GetGauge().GetValue() != 0 {
+				up = 0
+			}
+		}
+	}
+	g.up.Set(up)
+
+	own, e := g.registry.Gather()
+	if err == nil {
+		err = e
+	}
+	families = append(families, own...)
+	sort.Slice(families, func(i, j int) bool { return families[i].GetName() < families[j].GetName() })
+	return families, err
+}
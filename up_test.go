@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecadlabs/tezos_exporter/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpGatherer(t *testing.T) {
+	failed := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "tezos_rpc_failed"}, []string{"rpc"})
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(failed)
+	noWrap := func(reg prometheus.Registerer) prometheus.Registerer { return reg }
+
+	tests := []struct {
+		name   string
+		gate   *collector.StartupGate
+		failed float64
+		expect string
+	}{
+		{name: "ok", expect: "1"},
+		{name: "failed", failed: 1, expect: "0"},
+		// No RPC calls are made before the gate opens
+		{name: "gate closed", gate: collector.NewStartupGate(nil, "main", time.Hour, false, 0, 0), expect: "0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			failed.WithLabelValues("/network/stat").Set(test.failed)
+			g := NewUpGatherer(reg, test.gate, "", noWrap)
+			require.NoError(t, testutil.GatherAndCompare(g, strings.NewReader(`
+# HELP tezos_node_up A gauge that is set to 1 when all node RPC calls made during the scrape succeeded, 0 otherwise or before the monitors are started.
+# TYPE tezos_node_up gauge
+tezos_node_up `+test.expect+`
+`), "tezos_node_up"))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ecadlabs/tezos_exporter/collector"
+)
+
+// WatchHandler manages operations tracked by ConfirmationCollector for clients presenting the bearer token.
+// GET lists watched operations with their confirmation depth, PUT and DELETE with the hash query parameter add or remove one.
+type WatchHandler struct {
+	confirmations *collector.ConfirmationCollector
+	token         string
+}
+
+func (h *WatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !authorized(w, r, h.token) {
+		return
+	}
+
+	hash := r.URL.Query().Get("hash")
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(h.confirmations.Watched())
+
+	case http.MethodPut, http.MethodPost:
+		if hash == "" {
+			http.Error(w, "missing hash", http.StatusBadRequest)
+			return
+		}
+		if err := h.confirmations.Watch(hash); err != nil {
+			http.Error(w, err.Error(), http.StatusInsufficientStorage)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case http.MethodDelete:
+		if hash == "" {
+			http.Error(w, "missing hash", http.StatusBadRequest)
+			return
+		}
+		if !h.confirmations.Unwatch(hash) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST, DELETE")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}